@@ -1,8 +1,12 @@
 package assets
 
 import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
 	"time"
 
+	"github.com/mitchellh/goamz/s3"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -18,3 +22,25 @@ func FromURL(deps Deps, url string) (ref Asset, err error) {
 	err = deps.Mgo().C("remote_assets").Insert(&ref)
 	return
 }
+
+// FromBytes hosts given data into S3 and tracks it as an asset.
+func FromBytes(deps Deps, related string, data []byte, ext string) (ref Asset, err error) {
+	ref = Asset{
+		ID:       bson.NewObjectId(),
+		DataType: http.DetectContentType(data),
+		Status:   "hosted",
+		Created:  time.Now(),
+		Updated:  time.Now(),
+	}
+	hasher := md5.New()
+	hasher.Write(data)
+	ref.MD5 = hex.EncodeToString(hasher.Sum(nil))
+	path := related + "/" + ref.ID.Hex() + ext
+	err = deps.S3().Put(path, data, ref.DataType, s3.ACL("public-read"))
+	if err != nil {
+		return
+	}
+	ref.Hosted = path
+	err = deps.Mgo().C("remote_assets").Insert(&ref)
+	return
+}
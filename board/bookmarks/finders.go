@@ -0,0 +1,93 @@
+package bookmarks
+
+import (
+	"errors"
+
+	"github.com/tryanzu/core/board/comments"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/core/common"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var BookmarkNotFound = errors.New("Bookmark has not been found by given criteria.")
+
+// Page of user bookmarks, latest first.
+type Page struct {
+	Collection *string
+	Related    string
+	Before     *bson.ObjectId
+	Limit      int
+}
+
+// FindList of user bookmarks along with the saved items. Bookmarks of
+// deleted items are left out, so pages can come shorter than their limit.
+// Next points to the last bookmark read when more could follow.
+func FindList(d deps, userID bson.ObjectId, p Page) (list Bookmarks, next *bson.ObjectId, err error) {
+	var all Bookmarks
+	criteria := bson.M{"user_id": userID}
+	if p.Collection != nil {
+		criteria["collection"] = *p.Collection
+		if len(*p.Collection) == 0 {
+			criteria["collection"] = bson.M{"$exists": false}
+		}
+	}
+	if len(p.Related) > 0 {
+		criteria["related"] = p.Related
+	}
+	if p.Before != nil {
+		criteria["_id"] = bson.M{"$lt": *p.Before}
+	}
+	err = d.Mgo().C("bookmarks").Find(criteria).Sort("-_id").Limit(p.Limit).All(&all)
+	if err != nil {
+		return
+	}
+	if len(all) == p.Limit && p.Limit > 0 {
+		next = &all[len(all)-1].ID
+	}
+	plist, err := posts.FindList(d, common.WithinID(all.IDs("post")), common.SoftDelete)
+	if err != nil {
+		return
+	}
+	clist, err := comments.FindList(d, common.WithinID(all.IDs("comment")), common.SoftDelete)
+	if err != nil {
+		return
+	}
+	pmap, cmap := plist.Map(), clist.Map()
+	list = Bookmarks{}
+	for _, b := range all {
+		switch b.Related {
+		case "post":
+			if post, exists := pmap[b.RelatedID]; exists {
+				post.Content = ""
+				b.Post = &post
+				list = append(list, b)
+			}
+		case "comment":
+			if comment, exists := cmap[b.RelatedID]; exists {
+				b.Comment = &comment
+				list = append(list, b)
+			}
+		}
+	}
+	return
+}
+
+// FindOne bookmark of a user.
+func FindOne(d deps, userID bson.ObjectId, related string, id bson.ObjectId) (b Bookmark, err error) {
+	err = d.Mgo().C("bookmarks").Find(bson.M{"user_id": userID, "related": related, "related_id": id}).One(&b)
+	if err != nil {
+		err = BookmarkNotFound
+	}
+	return
+}
+
+// FindCollections of a user with their bookmarks count.
+func FindCollections(d deps, userID bson.ObjectId) (list []Collection, err error) {
+	list = []Collection{}
+	err = d.Mgo().C("bookmarks").Pipe([]bson.M{
+		{"$match": bson.M{"user_id": userID, "collection": bson.M{"$exists": true}}},
+		{"$group": bson.M{"_id": "$collection", "count": bson.M{"$sum": 1}}},
+		{"$sort": bson.M{"_id": 1}},
+	}).All(&list)
+	return
+}
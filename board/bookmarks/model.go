@@ -0,0 +1,41 @@
+package bookmarks
+
+import (
+	"time"
+
+	"github.com/tryanzu/core/board/comments"
+	posts "github.com/tryanzu/core/board/posts"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Bookmark of a post or comment, saved by a user into an optional named collection.
+type Bookmark struct {
+	ID         bson.ObjectId     `bson:"_id,omitempty" json:"id"`
+	UserID     bson.ObjectId     `bson:"user_id" json:"user_id"`
+	Related    string            `bson:"related" json:"related"`
+	RelatedID  bson.ObjectId     `bson:"related_id" json:"related_id"`
+	Collection string            `bson:"collection,omitempty" json:"collection,omitempty"`
+	Created    time.Time         `bson:"created_at" json:"created_at"`
+	Post       *posts.Post       `bson:"-" json:"post,omitempty"`
+	Comment    *comments.Comment `bson:"-" json:"comment,omitempty"`
+}
+
+// Bookmarks list.
+type Bookmarks []Bookmark
+
+// Collection of bookmarks by its name.
+type Collection struct {
+	Name  string `bson:"_id" json:"name"`
+	Count int    `bson:"count" json:"count"`
+}
+
+// IDs of bookmarked items of given type.
+func (list Bookmarks) IDs(related string) []bson.ObjectId {
+	ids := []bson.ObjectId{}
+	for _, b := range list {
+		if b.Related == related {
+			ids = append(ids, b.RelatedID)
+		}
+	}
+	return ids
+}
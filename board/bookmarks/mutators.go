@@ -0,0 +1,76 @@
+package bookmarks
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tryanzu/core/board/comments"
+	posts "github.com/tryanzu/core/board/posts"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MaxCollectionName length.
+const MaxCollectionName = 40
+
+var InvalidBookmark = errors.New("Invalid bookmark, check the saved item and collection name.")
+
+// Save an item into user bookmarks. Saving it again moves it to the given collection.
+func Save(d deps, userID bson.ObjectId, related string, id bson.ObjectId, collection string) (Bookmark, error) {
+	collection = strings.TrimSpace(collection)
+	if len(collection) > MaxCollectionName {
+		return Bookmark{}, InvalidBookmark
+	}
+	switch related {
+	case "post":
+		post, err := posts.FindId(d, id)
+		if err != nil || post.Deleted.IsZero() == false {
+			return Bookmark{}, InvalidBookmark
+		}
+	case "comment":
+		comment, err := comments.FindId(d, id)
+		if err != nil || comment.Deleted != nil {
+			return Bookmark{}, InvalidBookmark
+		}
+	default:
+		return Bookmark{}, InvalidBookmark
+	}
+
+	b, err := FindOne(d, userID, related, id)
+	if err == nil {
+		b.Collection = collection
+		err = d.Mgo().C("bookmarks").UpdateId(b.ID, b)
+		return b, err
+	}
+	b = Bookmark{
+		ID:         bson.NewObjectId(),
+		UserID:     userID,
+		Related:    related,
+		RelatedID:  id,
+		Collection: collection,
+		Created:    time.Now(),
+	}
+	err = d.Mgo().C("bookmarks").Insert(&b)
+	if err != nil {
+		return b, err
+	}
+	err = d.Mgo().C(related+"s").UpdateId(id, bson.M{"$inc": bson.M{"bookmarks": 1}})
+	return b, err
+}
+
+// Remove an item from user bookmarks.
+func Remove(d deps, userID bson.ObjectId, related string, id bson.ObjectId) error {
+	err := d.Mgo().C("bookmarks").Remove(bson.M{"user_id": userID, "related": related, "related_id": id})
+	if err == mgo.ErrNotFound {
+		return BookmarkNotFound
+	}
+	if err != nil {
+		return err
+	}
+	err = d.Mgo().C(related+"s").Update(bson.M{"_id": id, "bookmarks": bson.M{"$gt": 0}}, bson.M{"$inc": bson.M{"bookmarks": -1}})
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,11 @@
+package bounties
+
+import (
+	"github.com/tryanzu/core/board/legacy/model"
+	"gopkg.in/mgo.v2"
+)
+
+type deps interface {
+	Mgo() *mgo.Database
+	GamingConfig() *model.GamingRules
+}
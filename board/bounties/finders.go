@@ -0,0 +1,37 @@
+package bounties
+
+import (
+	"errors"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var BountyNotFound = errors.New("Bounty has not been found by given criteria.")
+
+// FindByPost gets the latest bounty offered for a post.
+func FindByPost(d deps, postID bson.ObjectId) (b Bounty, err error) {
+	err = d.Mgo().C("bounties").Find(bson.M{"post_id": postID}).Sort("-created_at").One(&b)
+	if err != nil {
+		err = BountyNotFound
+	}
+	return
+}
+
+// FindOpen bounty of a post.
+func FindOpen(d deps, postID bson.ObjectId) (b Bounty, err error) {
+	err = d.Mgo().C("bounties").Find(bson.M{"post_id": postID, "status": StatusOpen}).One(&b)
+	if err != nil {
+		err = BountyNotFound
+	}
+	return
+}
+
+// FindExpired open bounties.
+func FindExpired(d deps, now time.Time) (list []Bounty, err error) {
+	err = d.Mgo().C("bounties").Find(bson.M{
+		"status":     StatusOpen,
+		"expires_at": bson.M{"$lte": now},
+	}).All(&list)
+	return
+}
@@ -0,0 +1,28 @@
+package bounties
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Bounty statuses.
+const (
+	StatusOpen     = "open"
+	StatusAwarded  = "awarded"
+	StatusRefunded = "refunded"
+)
+
+// Bounty of coins escrowed by a question author until an answer gets accepted.
+type Bounty struct {
+	ID        bson.ObjectId  `bson:"_id,omitempty" json:"id"`
+	PostID    bson.ObjectId  `bson:"post_id" json:"post_id"`
+	UserID    bson.ObjectId  `bson:"user_id" json:"user_id"`
+	Coins     int            `bson:"coins" json:"coins"`
+	Status    string         `bson:"status" json:"status"`
+	WinnerID  *bson.ObjectId `bson:"winner_id,omitempty" json:"winner_id,omitempty"`
+	CommentID *bson.ObjectId `bson:"comment_id,omitempty" json:"comment_id,omitempty"`
+	Expires   time.Time      `bson:"expires_at" json:"expires_at"`
+	Created   time.Time      `bson:"created_at" json:"created_at"`
+	Updated   time.Time      `bson:"updated_at" json:"updated_at"`
+}
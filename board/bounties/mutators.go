@@ -0,0 +1,89 @@
+package bounties
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tryanzu/core/modules/gaming"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	InvalidBounty = errors.New("Bounties need a positive amount of coins and a valid duration.")
+	BountyExists  = errors.New("Post already has an open bounty.")
+	BountyClosed  = errors.New("Bounty is no longer open.")
+)
+
+// Offer a bounty for a question, coins are taken from the asker right away.
+func Offer(d deps, postID, userID bson.ObjectId, coins int, expires time.Time) (b Bounty, err error) {
+	if coins <= 0 || expires.Before(time.Now()) {
+		err = InvalidBounty
+		return
+	}
+	if _, err = FindOpen(d, postID); err == nil {
+		err = BountyExists
+		return
+	}
+	err = gaming.SpendUserCoins(d, userID, coins)
+	if err != nil {
+		return
+	}
+	b = Bounty{
+		ID:      bson.NewObjectId(),
+		PostID:  postID,
+		UserID:  userID,
+		Coins:   coins,
+		Status:  StatusOpen,
+		Expires: expires,
+		Created: time.Now(),
+		Updated: time.Now(),
+	}
+	err = d.Mgo().C("bounties").Insert(&b)
+	if err != nil {
+		// Give the escrowed coins back.
+		gaming.IncreaseUserCoins(d, userID, coins)
+	}
+	return
+}
+
+// Award escrowed coins to the accepted answer author. Askers
+// accepting their own answer just get their coins back.
+func Award(d deps, b Bounty, commentID, winnerID bson.ObjectId) (Bounty, error) {
+	if winnerID == b.UserID {
+		return Refund(d, b)
+	}
+	b, err := settle(d, b, StatusAwarded, bson.M{"winner_id": winnerID, "comment_id": commentID})
+	if err != nil {
+		return b, err
+	}
+	b.WinnerID = &winnerID
+	b.CommentID = &commentID
+	err = gaming.IncreaseUserCoins(d, winnerID, b.Coins)
+	return b, err
+}
+
+// Refund escrowed coins to the asker.
+func Refund(d deps, b Bounty) (Bounty, error) {
+	b, err := settle(d, b, StatusRefunded, bson.M{})
+	if err != nil {
+		return b, err
+	}
+	err = gaming.IncreaseUserCoins(d, b.UserID, b.Coins)
+	return b, err
+}
+
+// settle an open bounty only once, so coins never move twice.
+func settle(d deps, b Bounty, status string, set bson.M) (Bounty, error) {
+	set["status"] = status
+	set["updated_at"] = time.Now()
+	err := d.Mgo().C("bounties").Update(bson.M{"_id": b.ID, "status": StatusOpen}, bson.M{"$set": set})
+	if err == mgo.ErrNotFound {
+		return b, BountyClosed
+	}
+	if err != nil {
+		return b, err
+	}
+	b.Status = status
+	return b, nil
+}
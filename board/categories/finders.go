@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/tryanzu/core/core/config"
+	"gopkg.in/mgo.v2/bson"
 )
 
 var (
@@ -49,3 +50,15 @@ func makeTree(d deps) (list Categories) {
 	list = parent
 	return
 }
+
+// FindWithPolicy categories having inactive posts rules.
+func FindWithPolicy(d deps) (list Categories, err error) {
+	err = d.Mgo().C("categories").Find(bson.M{"policy": bson.M{"$exists": true}}).All(&list)
+	return
+}
+
+// FindId category.
+func FindId(d deps, id bson.ObjectId) (c Category, err error) {
+	err = d.Mgo().C("categories").FindId(id).One(&c)
+	return
+}
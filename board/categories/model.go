@@ -15,6 +15,7 @@ type Category struct {
 	Parent      bson.ObjectId `bson:"parent,omitempty" json:"parent,omitempty"`
 	ReactSet    []string      `bson:"reactSet" json:"-"`
 	Order       int           `bson:"order,omitempty" json:"order,omitempty"`
+	Policy      Policy        `bson:"policy,omitempty" json:"policy"`
 
 	// Runtime computed properties.
 	Child     Categories `bson:"-" json:"subcategories,omitempty"`
@@ -31,6 +32,20 @@ type ACL struct {
 	Write []string `bson:"write" json:"write"`
 }
 
+// Policy for posts of a category that go inactive. Zero values disable them.
+type Policy struct {
+	// LockAfter days without activity posts are locked.
+	LockAfter int `bson:"lock_after,omitempty" json:"lock_after"`
+
+	// ArchiveAfter months without activity posts are archived.
+	ArchiveAfter int `bson:"archive_after,omitempty" json:"archive_after"`
+}
+
+// Enabled tells whether any rule applies.
+func (p Policy) Enabled() bool {
+	return p.LockAfter > 0 || p.ArchiveAfter > 0
+}
+
 // CheckWrite permissions for categories tree.
 func (slice Categories) CheckWrite(fn func([]string) bool) Categories {
 	list := make(Categories, len(slice))
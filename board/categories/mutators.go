@@ -0,0 +1,18 @@
+package categories
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// UpdatePolicy of a category, disabled policies are removed.
+func UpdatePolicy(d deps, id bson.ObjectId, policy Policy) (err error) {
+	update := bson.M{"$set": bson.M{"policy": policy}}
+	if policy.Enabled() == false {
+		update = bson.M{"$unset": bson.M{"policy": ""}}
+	}
+	err = d.Mgo().C("categories").UpdateId(id, update)
+	if err == nil {
+		cachedAt = nil
+	}
+	return
+}
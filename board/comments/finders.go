@@ -2,7 +2,10 @@ package comments
 
 import (
 	"errors"
+	"sort"
+	"strconv"
 
+	"github.com/tryanzu/core/board/ranking"
 	"github.com/tryanzu/core/core/common"
 	"github.com/tryanzu/core/core/content"
 	mgo "gopkg.in/mgo.v2"
@@ -81,6 +84,13 @@ func FindId(deps Deps, id bson.ObjectId) (comment Comment, err error) {
 	return
 }
 
+// FindRevisions of a comment, oldest first.
+func FindRevisions(deps Deps, commentID bson.ObjectId) (list Revisions, err error) {
+	list = Revisions{}
+	err = deps.Mgo().C("comment_revisions").Find(bson.M{"comment_id": commentID}).Sort("created_at", "_id").All(&list)
+	return
+}
+
 func FindList(deps Deps, scopes ...common.Scope) (list Comments, err error) {
 	err = deps.Mgo().C("comments").Find(common.ByScope(scopes...)).All(&list)
 	return
@@ -99,3 +109,328 @@ func FindReplies(deps Deps, list Comments, max int) (lists []Replies, err error)
 	}).All(&lists)
 	return
 }
+
+// At most this many replies are loaded for a single tree.
+const treeLimit = 1000
+
+// Flat comments of a post, threads included, oldest first.
+func Flat(id bson.ObjectId, limit int, after *bson.ObjectId) common.Query {
+	return func(col *mgo.Collection) *mgo.Query {
+		criteria := bson.M{
+			"$or": []bson.M{
+				{"reply_type": "post", "reply_to": id},
+				{"post_id": id},
+			},
+			"deleted_at": bson.M{"$exists": false},
+		}
+		if after != nil {
+			criteria["_id"] = bson.M{"$gt": after}
+		}
+		return col.Find(criteria).Limit(limit).Sort("_id")
+	}
+}
+
+// RepliesTo a comment after given cursor, oldest first.
+func RepliesTo(id bson.ObjectId, limit int, after *bson.ObjectId) common.Query {
+	return func(col *mgo.Collection) *mgo.Query {
+		criteria := bson.M{
+			"reply_type": "comment",
+			"reply_to":   id,
+			"deleted_at": bson.M{"$exists": false},
+		}
+		if after != nil {
+			criteria["_id"] = bson.M{"$gt": after}
+		}
+		return col.Find(criteria).Limit(limit).Sort("_id")
+	}
+}
+
+// FindTree of replies under given comments, down to depth levels below them
+// and with at most max replies per comment.
+func FindTree(deps Deps, roots Comments, depth, max int) (Nodes, error) {
+	if len(roots) == 0 {
+		return Nodes{}, nil
+	}
+	ids := make([]bson.ObjectId, len(roots))
+	deepest := 0
+	for n, c := range roots {
+		ids[n] = c.Id
+		if c.Depth() > deepest {
+			deepest = c.Depth()
+		}
+	}
+	var counts []struct {
+		ID    bson.ObjectId `bson:"_id"`
+		Count int           `bson:"count"`
+	}
+	err := deps.Mgo().C("comments").Pipe([]bson.M{
+		{"$match": bson.M{
+			"ancestors":  bson.M{"$in": ids},
+			"deleted_at": bson.M{"$exists": false},
+		}},
+		{"$group": bson.M{"_id": "$reply_to", "count": bson.M{"$sum": 1}}},
+	}).All(&counts)
+	if err != nil {
+		return nil, err
+	}
+	var replies Comments
+	if depth > 0 {
+		err = deps.Mgo().C("comments").Find(bson.M{
+			"ancestors":  bson.M{"$in": ids},
+			"deleted_at": bson.M{"$exists": false},
+			"ancestors." + strconv.Itoa(deepest+depth): bson.M{"$exists": false},
+		}).Sort("_id").Limit(treeLimit).All(&replies)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var processed content.Parseable
+	for n, c := range replies {
+		processed, err = content.Postprocess(deps, c)
+		if err != nil {
+			return nil, err
+		}
+		replies[n] = processed.(Comment)
+	}
+	replies, err = replies.WithUsers(deps)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[bson.ObjectId]int, len(counts))
+	for _, c := range counts {
+		m[c.ID] = c.Count
+	}
+	return buildTree(roots, replies, m, depth, max), nil
+}
+
+// buildTree nests replies (oldest first) under their parents.
+func buildTree(roots, replies Comments, counts map[bson.ObjectId]int, depth, max int) Nodes {
+	children := map[bson.ObjectId]Comments{}
+	for _, r := range replies {
+		children[r.ReplyTo] = append(children[r.ReplyTo], r)
+	}
+	var build func(list Comments, level int) Nodes
+	build = func(list Comments, level int) Nodes {
+		nodes := make(Nodes, len(list))
+		for n, c := range list {
+			nodes[n] = Node{Comment: c, Count: counts[c.Id], Children: []Node{}}
+			if level >= depth {
+				continue
+			}
+			kids := children[c.Id]
+			if len(kids) > max {
+				kids = kids[:max]
+			}
+			nodes[n].Children = build(kids, level+1)
+			if len(kids) > 0 && nodes[n].Count > len(kids) {
+				next := kids[len(kids)-1].Id
+				nodes[n].Next = &next
+			}
+		}
+		return nodes
+	}
+	return build(roots, 0)
+}
+
+// Ancestry of a reply to given comment, from its top level comment down to
+// the comment itself, along with the post they belong to.
+func Ancestry(deps Deps, parent bson.ObjectId) (ancestors []bson.ObjectId, postID bson.ObjectId, err error) {
+	chain := []bson.ObjectId{}
+	id := parent
+	for {
+		var ref Comment
+		ref, err = FindId(deps, id)
+		if err != nil {
+			return
+		}
+		chain = append(chain, ref.Id)
+		if ref.ReplyType == "post" || len(ref.Ancestors) > 0 {
+			ancestors = append([]bson.ObjectId{}, ref.Ancestors...)
+			postID = ref.RelatedPost()
+			break
+		}
+		id = ref.ReplyTo
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		ancestors = append(ancestors, chain[i])
+	}
+	return
+}
+
+// Sorts top level comments can be listed by.
+var Sorts = map[string]bool{
+	"best":          true,
+	"top":           true,
+	"controversial": true,
+	"new":           true,
+	"old":           true,
+}
+
+// At most this many comments of a post are scored to sort them.
+const sortLimit = 2000
+
+// FindSorted top level comments of a post. Scored sorts rank comments by their
+// reactions, weighted with given weights.
+func FindSorted(deps Deps, postID bson.ObjectId, by string, w ranking.Weights, limit, offset int) (CommentsSet, error) {
+	criteria := bson.M{
+		"reply_type": "post",
+		"reply_to":   postID,
+		"deleted_at": bson.M{"$exists": false},
+	}
+	switch by {
+	case "new", "old":
+		order := "-created_at"
+		if by == "old" {
+			order = "created_at"
+		}
+		return FetchBy(deps, func(col *mgo.Collection) *mgo.Query {
+			return col.Find(criteria).Sort(order).Skip(offset).Limit(limit)
+		})
+	}
+
+	var all Comments
+	err := deps.Mgo().C("comments").Find(criteria).Select(bson.M{"votes": 1, "created_at": 1}).Sort("-created_at").Limit(sortLimit).All(&all)
+	if err != nil {
+		return CommentsSet{}, err
+	}
+	count, err := deps.Mgo().C("comments").Find(criteria).Count()
+	if err != nil {
+		return CommentsSet{}, err
+	}
+	ids := sortByScore(all, by, w)
+	if offset > len(ids) {
+		offset = len(ids)
+	}
+	ids = ids[offset:]
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	set, err := FetchBy(deps, func(col *mgo.Collection) *mgo.Query {
+		return col.Find(bson.M{"_id": bson.M{"$in": ids}})
+	})
+	if err != nil {
+		return CommentsSet{}, err
+	}
+	m := set.List.Map()
+	list := make(Comments, 0, len(ids))
+	for _, id := range ids {
+		if c, exists := m[id]; exists {
+			list = append(list, c)
+		}
+	}
+	return CommentsSet{List: list, Count: count}, nil
+}
+
+// sortByScore comments ids, newer ones first on equal scores.
+func sortByScore(all Comments, by string, w ranking.Weights) []bson.ObjectId {
+	scores := make(map[bson.ObjectId]float64, len(all))
+	for _, c := range all {
+		up, down := w.Split(c.Votes)
+		switch by {
+		case "best":
+			scores[c.Id] = ranking.Confidence(up, down)
+		case "top":
+			scores[c.Id] = up - down
+		case "controversial":
+			scores[c.Id] = ranking.Controversy(up, down)
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return scores[all[i].Id] > scores[all[j].Id]
+	})
+	ids := make([]bson.ObjectId, len(all))
+	for n, c := range all {
+		ids[n] = c.Id
+	}
+	return ids
+}
+
+// FindContext of a comment in a post with up to n siblings on each side.
+func FindContext(deps Deps, postID, id bson.ObjectId, n int) (ctx Context, err error) {
+	if n < 1 {
+		n = 1
+	}
+	c, err := FindId(deps, id)
+	if err != nil || c.Deleted != nil || c.RelatedPost() != postID {
+		err = CommentNotFound
+		return
+	}
+	ancestors := c.Ancestors
+	if c.ReplyType == "comment" && len(ancestors) == 0 {
+		ancestors, _, err = Ancestry(deps, c.ReplyTo)
+		if err != nil {
+			return
+		}
+	}
+	var parents Comments
+	err = deps.Mgo().C("comments").Find(bson.M{"_id": bson.M{"$in": ancestors}}).All(&parents)
+	if err != nil {
+		return
+	}
+	m := parents.Map()
+	ctx.Parents = Comments{}
+	for _, id := range ancestors {
+		if p, exists := m[id]; exists {
+			ctx.Parents = append(ctx.Parents, p)
+		}
+	}
+
+	siblings := bson.M{
+		"reply_type": c.ReplyType,
+		"reply_to":   c.ReplyTo,
+		"deleted_at": bson.M{"$exists": false},
+	}
+	siblings["_id"] = bson.M{"$lt": c.Id}
+	err = deps.Mgo().C("comments").Find(siblings).Sort("-_id").Limit(n + 1).All(&ctx.Before)
+	if err != nil {
+		return
+	}
+	siblings["_id"] = bson.M{"$gt": c.Id}
+	err = deps.Mgo().C("comments").Find(siblings).Sort("_id").Limit(n + 1).All(&ctx.After)
+	if err != nil {
+		return
+	}
+	ctx.Before, ctx.After, ctx.Prev, ctx.Next = siblingWindow(ctx.Before, ctx.After, n)
+	processed, err := content.Postprocess(deps, c)
+	if err != nil {
+		return
+	}
+	ctx.Comment = processed.(Comment)
+	for _, list := range []Comments{ctx.Parents, ctx.Before, ctx.After} {
+		for i, c := range list {
+			processed, err = content.Postprocess(deps, c)
+			if err != nil {
+				return
+			}
+			list[i] = processed.(Comment)
+		}
+	}
+	return
+}
+
+// siblingWindow of n siblings at most on each side, given the ones before
+// read nearest first. Before comes back oldest first, cursors point to the
+// farthest sibling loaded on each side when more could follow.
+func siblingWindow(before, after Comments, n int) (Comments, Comments, *bson.ObjectId, *bson.ObjectId) {
+	var prev, next *bson.ObjectId
+	if len(before) > n {
+		before = before[:n]
+		id := before[n-1].Id
+		prev = &id
+	}
+	if len(after) > n {
+		after = after[:n]
+		id := after[n-1].Id
+		next = &id
+	}
+	for i, j := 0, len(before)-1; i < j; i, j = i+1, j-1 {
+		before[i], before[j] = before[j], before[i]
+	}
+	if before == nil {
+		before = Comments{}
+	}
+	if after == nil {
+		after = Comments{}
+	}
+	return before, after, prev, next
+}
@@ -0,0 +1,69 @@
+package comments
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestBuildTree(t *testing.T) {
+	Convey("Replies are nested under their parents", t, func() {
+		root := Comment{Id: bson.NewObjectId()}
+		a := Comment{Id: bson.NewObjectId(), ReplyTo: root.Id, Ancestors: []bson.ObjectId{root.Id}}
+		b := Comment{Id: bson.NewObjectId(), ReplyTo: root.Id, Ancestors: []bson.ObjectId{root.Id}}
+		c := Comment{Id: bson.NewObjectId(), ReplyTo: root.Id, Ancestors: []bson.ObjectId{root.Id}}
+		aa := Comment{Id: bson.NewObjectId(), ReplyTo: a.Id, Ancestors: []bson.ObjectId{root.Id, a.Id}}
+		replies := Comments{a, b, aa, c}
+		counts := map[bson.ObjectId]int{root.Id: 3, a.Id: 1}
+
+		Convey("Down to the given depth", func() {
+			tree := buildTree(Comments{root}, replies, counts, 1, 5)
+			So(len(tree), ShouldEqual, 1)
+			So(len(tree[0].Children), ShouldEqual, 3)
+			So(tree[0].Children[0].Children, ShouldBeEmpty)
+			So(tree[0].Children[0].Count, ShouldEqual, 1)
+			So(tree[0].Next, ShouldBeNil)
+
+			tree = buildTree(Comments{root}, replies, counts, 2, 5)
+			So(tree[0].Children[0].Children[0].Id, ShouldEqual, aa.Id)
+			So(aa.Depth(), ShouldEqual, 2)
+		})
+
+		Convey("With a cursor to load more replies past the limit", func() {
+			tree := buildTree(Comments{root}, replies, counts, 2, 2)
+			So(len(tree[0].Children), ShouldEqual, 2)
+			So(*tree[0].Next, ShouldEqual, b.Id)
+			So(len(Nodes(tree).Comments()), ShouldEqual, 4)
+		})
+	})
+}
+
+func TestSiblingWindow(t *testing.T) {
+	Convey("Siblings around a comment come with cursors to the farthest ones", t, func() {
+		ids := make([]bson.ObjectId, 10)
+		for i := range ids {
+			ids[i] = bson.NewObjectId()
+		}
+		// Target is ids[5], siblings before are read nearest first.
+		before := Comments{{Id: ids[4]}, {Id: ids[3]}, {Id: ids[2]}}
+		after := Comments{{Id: ids[6]}, {Id: ids[7]}, {Id: ids[8]}}
+
+		Convey("When more siblings could follow", func() {
+			b, a, prev, next := siblingWindow(before, after, 2)
+			So(b[0].Id, ShouldEqual, ids[3])
+			So(b[1].Id, ShouldEqual, ids[4])
+			So(*prev, ShouldEqual, ids[3])
+			So(a[1].Id, ShouldEqual, ids[7])
+			So(*next, ShouldEqual, ids[7])
+		})
+
+		Convey("Without cursors when every sibling is loaded", func() {
+			b, a, prev, next := siblingWindow(Comments{{Id: ids[4]}}, nil, 2)
+			So(len(b), ShouldEqual, 1)
+			So(a, ShouldBeEmpty)
+			So(prev, ShouldBeNil)
+			So(next, ShouldBeNil)
+		})
+	})
+}
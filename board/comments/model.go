@@ -11,20 +11,24 @@ import (
 )
 
 type Comment struct {
-	Id        bson.ObjectId `bson:"_id,omitempty" json:"id,omitempty"`
-	UserId    bson.ObjectId `bson:"user_id" json:"user_id"`
-	PostId    bson.ObjectId `bson:"post_id,omitempty" json:"post_id,omitempty"`
-	Votes     votes.Votes   `bson:"votes" json:"votes"`
-	User      interface{}   `bson:"-" json:"author,omitempty"`
-	Position  int           `bson:"position" json:"-"`
-	Liked     int           `bson:"-" json:"liked,omitempty"`
-	Content   string        `bson:"content" json:"content"`
-	ReplyTo   bson.ObjectId `bson:"reply_to,omitempty" json:"reply_to,omitempty"`
-	ReplyType string        `bson:"reply_type,omitempty" json:"reply_type,omitempty"`
-	Chosen    bool          `bson:"chosen,omitempty" json:"chosen,omitempty"`
-	Created   time.Time     `bson:"created_at" json:"created_at"`
-	Updated   time.Time     `bson:"updated_at" json:"updated_at"`
-	Deleted   *time.Time    `bson:"deleted_at,omitempty" json:"-"`
+	Id        bson.ObjectId   `bson:"_id,omitempty" json:"id,omitempty"`
+	UserId    bson.ObjectId   `bson:"user_id" json:"user_id"`
+	PostId    bson.ObjectId   `bson:"post_id,omitempty" json:"post_id,omitempty"`
+	Votes     votes.Votes     `bson:"votes" json:"votes"`
+	User      interface{}     `bson:"-" json:"author,omitempty"`
+	Position  int             `bson:"position" json:"-"`
+	Liked     int             `bson:"-" json:"liked,omitempty"`
+	Content   string          `bson:"content" json:"content"`
+	ReplyTo   bson.ObjectId   `bson:"reply_to,omitempty" json:"reply_to,omitempty"`
+	ReplyType string          `bson:"reply_type,omitempty" json:"reply_type,omitempty"`
+	Ancestors []bson.ObjectId `bson:"ancestors,omitempty" json:"ancestors,omitempty"`
+	Chosen    bool            `bson:"chosen,omitempty" json:"chosen,omitempty"`
+	Created   time.Time       `bson:"created_at" json:"created_at"`
+	Updated   time.Time       `bson:"updated_at" json:"updated_at"`
+	Edited    *time.Time      `bson:"edited_at,omitempty" json:"edited_at,omitempty"`
+	EditedBy  *bson.ObjectId  `bson:"edited_by,omitempty" json:"edited_by,omitempty"`
+	Deleted   *time.Time      `bson:"deleted_at,omitempty" json:"-"`
+	DeletedBy *bson.ObjectId  `bson:"deleted_by,omitempty" json:"-"`
 
 	// Runtime generated fields.
 	Replies interface{} `bson:"-" json:"replies,omitempty"`
@@ -44,9 +48,17 @@ func (c Comment) GetParseableMeta() map[string]interface{} {
 	meta["id"] = c.Id
 	meta["related"] = "comment"
 	meta["user_id"] = c.UserId
+	meta["post_id"] = c.RelatedPost()
 	return meta
 }
 
+// HoldForReview hides the comment until a moderator reviews it.
+func (c Comment) HoldForReview() content.Parseable {
+	held := time.Now()
+	c.Deleted = &held
+	return c
+}
+
 func (c Comment) RelatedID() bson.ObjectId {
 	return c.ReplyTo
 }
@@ -66,6 +78,33 @@ func (c Comment) VotableID() bson.ObjectId {
 	return c.Id
 }
 
+// Depth of the comment in its post tree, top level comments are at 0.
+func (c Comment) Depth() int {
+	return len(c.Ancestors)
+}
+
+// Node of a comments tree. Replies are sorted oldest first, when there are
+// more than loaded they continue after Next.
+type Node struct {
+	Comment
+	Count    int            `json:"count"`
+	Children []Node         `json:"children"`
+	Next     *bson.ObjectId `json:"next,omitempty"`
+}
+
+// Nodes of a comments tree.
+type Nodes []Node
+
+// Comments in the tree, flattened.
+func (all Nodes) Comments() (list Comments) {
+	list = Comments{}
+	for _, n := range all {
+		list = append(list, n.Comment)
+		list = append(list, Nodes(n.Children).Comments()...)
+	}
+	return
+}
+
 type Replies struct {
 	Id    bson.ObjectId `bson:"_id,omitempty" json:"-"`
 	Count int           `bson:"count" json:"count"`
@@ -253,3 +292,45 @@ func (all Comments) VotesOf(deps Deps, userID bson.ObjectId) (list votes.List, e
 	})
 	return
 }
+
+// EditGrace after a comment is published its author can edit it without
+// leaving a revision behind.
+const EditGrace = 5 * time.Minute
+
+// InGrace tells whether edits on the comment are still untracked.
+func (c Comment) InGrace(now time.Time) bool {
+	return c.Edited == nil && now.Sub(c.Created) < EditGrace
+}
+
+// Revision holds the comment content after an edit. Edits made by someone
+// other than the author are marked as moderated.
+type Revision struct {
+	ID        bson.ObjectId `bson:"_id,omitempty" json:"id"`
+	CommentID bson.ObjectId `bson:"comment_id" json:"comment_id"`
+	UserID    bson.ObjectId `bson:"user_id" json:"user_id"`
+	Content   string        `bson:"content" json:"content"`
+	Reason    string        `bson:"reason,omitempty" json:"reason,omitempty"`
+	Moderated bool          `bson:"moderated,omitempty" json:"moderated,omitempty"`
+	Created   time.Time     `bson:"created_at" json:"created_at"`
+}
+
+// Revisions list.
+type Revisions []Revision
+
+// Context of a comment to show it within its thread. Siblings share its
+// parent and go oldest first, cursors continue past them.
+type Context struct {
+	Comment Comment        `json:"comment"`
+	Parents Comments       `json:"parents"`
+	Before  Comments       `json:"before"`
+	After   Comments       `json:"after"`
+	Prev    *bson.ObjectId `json:"prev,omitempty"`
+	Next    *bson.ObjectId `json:"next,omitempty"`
+}
+
+// All comments in the context.
+func (ctx Context) All() Comments {
+	list := append(Comments{ctx.Comment}, ctx.Parents...)
+	list = append(list, ctx.Before...)
+	return append(list, ctx.After...)
+}
@@ -8,13 +8,13 @@ import (
 	"gopkg.in/mgo.v2/bson"
 )
 
-// Delete comment.
-func Delete(deps Deps, c Comment) error {
+// Delete comment by given user.
+func Delete(deps Deps, c Comment, by bson.ObjectId) error {
 	if c.Deleted != nil {
 		return nil
 	}
 	err := deps.Mgo().C("comments").UpdateId(c.Id, bson.M{
-		"$set": bson.M{"deleted_at": time.Now()},
+		"$set": bson.M{"deleted_at": time.Now(), "deleted_by": by},
 	})
 	if err != nil {
 		return err
@@ -26,15 +26,23 @@ func Delete(deps Deps, c Comment) error {
 	return nil
 }
 
-func DeletePostComments(deps Deps, postID bson.ObjectId) error {
-	_, err := deps.Mgo().C("comments").UpdateAll(
-		bson.M{"$or": []bson.M{
-			{"post_id": postID},
-			{"reply_to": postID},
-		}}, bson.M{
-			"$set": bson.M{"deleted_at": time.Now()},
+// DeletePostComments along with their post. They are marked as deleted with
+// it, so restoring the post brings back only those.
+func DeletePostComments(deps Deps, postID bson.ObjectId) (int, error) {
+	info, err := deps.Mgo().C("comments").UpdateAll(
+		bson.M{
+			"$or": []bson.M{
+				{"post_id": postID},
+				{"reply_to": postID},
+			},
+			"deleted_at": bson.M{"$exists": false},
+		}, bson.M{
+			"$set": bson.M{"deleted_at": time.Now(), "deleted_with": postID},
 		})
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return info.Updated, nil
 }
 
 // UpsertComment performs validations before upserting data struct
@@ -44,20 +52,10 @@ func UpsertComment(deps Deps, c Comment) (comment Comment, err error) {
 		c.Created = time.Now()
 	}
 
-	if c.ReplyType == "comment" && c.PostId.Valid() == false {
-		id := c.ReplyTo
-		for {
-			var ref Comment
-			ref, err = FindId(deps, id)
-			if err != nil {
-				return
-			}
-			id = ref.ReplyTo
-			if ref.ReplyType == "post" {
-				c.PostId = ref.ReplyTo
-				break
-			}
-			continue
+	if c.ReplyType == "comment" && (c.PostId.Valid() == false || len(c.Ancestors) == 0) {
+		c.Ancestors, c.PostId, err = Ancestry(deps, c.ReplyTo)
+		if err != nil {
+			return
 		}
 	}
 
@@ -71,12 +69,17 @@ func UpsertComment(deps Deps, c Comment) (comment Comment, err error) {
 	}
 
 	c = processed.(Comment)
-	changes, err := deps.Mgo().C("comments").UpsertId(c.Id, bson.M{"$set": c})
+	update := bson.M{"$set": c}
+	if c.EditedBy == nil {
+		update["$unset"] = bson.M{"edited_by": ""}
+	}
+	changes, err := deps.Mgo().C("comments").UpsertId(c.Id, update)
 	if err != nil {
 		return
 	}
 
-	if changes.Matched == 0 {
+	// Comments held for review do not count until a moderator releases them.
+	if changes.Matched == 0 && c.Deleted == nil {
 		if c.ReplyType == "post" {
 			err = deps.Mgo().C("posts").UpdateId(c.ReplyTo, bson.M{
 				"$inc":      bson.M{"comments.count": 1},
@@ -104,3 +107,58 @@ func UpsertComment(deps Deps, c Comment) (comment Comment, err error) {
 	comment = c
 	return
 }
+
+// Accept comment as the answer of its post, replacing any previously accepted one.
+func Accept(deps Deps, c Comment) error {
+	pid := c.RelatedPost()
+	_, err := deps.Mgo().C("comments").UpdateAll(bson.M{
+		"$or": []bson.M{
+			{"post_id": pid},
+			{"reply_to": pid},
+		},
+		"chosen": true,
+	}, bson.M{"$unset": bson.M{"chosen": 1}})
+	if err != nil {
+		return err
+	}
+	err = deps.Mgo().C("comments").UpdateId(c.Id, bson.M{"$set": bson.M{"chosen": true}})
+	if err != nil {
+		return err
+	}
+	return deps.Mgo().C("posts").UpdateId(pid, bson.M{"$set": bson.M{"solved": true}})
+}
+
+// Unaccept comment as the answer of its post.
+func Unaccept(deps Deps, c Comment) error {
+	err := deps.Mgo().C("comments").UpdateId(c.Id, bson.M{"$unset": bson.M{"chosen": 1}})
+	if err != nil {
+		return err
+	}
+	return deps.Mgo().C("posts").UpdateId(c.RelatedPost(), bson.M{"$unset": bson.M{"solved": 1}})
+}
+
+// TrackRevision stores a comment revision. Comments edited for the first
+// time get their content before the edit stored as the original revision.
+func TrackRevision(deps Deps, before Comment, r Revision) (Revision, error) {
+	n, err := deps.Mgo().C("comment_revisions").Find(bson.M{"comment_id": before.Id}).Count()
+	if err != nil {
+		return r, err
+	}
+	if n == 0 {
+		err = deps.Mgo().C("comment_revisions").Insert(Revision{
+			ID:        bson.NewObjectId(),
+			CommentID: before.Id,
+			UserID:    before.UserId,
+			Content:   before.Content,
+			Created:   before.Created,
+		})
+		if err != nil {
+			return r, err
+		}
+	}
+	r.ID = bson.NewObjectId()
+	r.CommentID = before.Id
+	r.Created = time.Now()
+	err = deps.Mgo().C("comment_revisions").Insert(&r)
+	return r, err
+}
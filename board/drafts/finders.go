@@ -0,0 +1,24 @@
+package drafts
+
+import (
+	"errors"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var DraftNotFound = errors.New("Draft has not been found by given criteria.")
+
+// FindList of user drafts, latest saved first.
+func FindList(d deps, userID bson.ObjectId) (list Drafts, err error) {
+	list = Drafts{}
+	err = d.Mgo().C("drafts").Find(bson.M{"user_id": userID}).Sort("-updated_at").All(&list)
+	return
+}
+
+func FindId(d deps, userID, id bson.ObjectId) (draft Draft, err error) {
+	err = d.Mgo().C("drafts").Find(bson.M{"_id": id, "user_id": userID}).One(&draft)
+	if err != nil {
+		err = DraftNotFound
+	}
+	return
+}
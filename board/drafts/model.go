@@ -0,0 +1,22 @@
+package drafts
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Draft of a post being written by its author.
+type Draft struct {
+	ID         bson.ObjectId  `bson:"_id,omitempty" json:"id"`
+	UserID     bson.ObjectId  `bson:"user_id" json:"user_id"`
+	Title      string         `bson:"title" json:"title"`
+	Content    string         `bson:"content" json:"content"`
+	Category   *bson.ObjectId `bson:"category,omitempty" json:"category,omitempty"`
+	IsQuestion bool           `bson:"is_question" json:"is_question"`
+	Created    time.Time      `bson:"created_at" json:"created_at"`
+	Updated    time.Time      `bson:"updated_at" json:"updated_at"`
+}
+
+// Drafts list.
+type Drafts []Draft
@@ -0,0 +1,48 @@
+package drafts
+
+import (
+	"errors"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MaxDrafts a user can keep at once.
+const MaxDrafts = 50
+
+var TooManyDrafts = errors.New("Too many drafts, delete some before saving new ones.")
+
+// UpsertDraft autosaves a draft. Existing drafts can only be saved by their author.
+func UpsertDraft(d deps, draft Draft) (Draft, error) {
+	draft.Updated = time.Now()
+	if draft.ID.Valid() {
+		current, err := FindId(d, draft.UserID, draft.ID)
+		if err != nil {
+			return draft, err
+		}
+		draft.Created = current.Created
+		err = d.Mgo().C("drafts").UpdateId(draft.ID, draft)
+		return draft, err
+	}
+
+	n, err := d.Mgo().C("drafts").Find(bson.M{"user_id": draft.UserID}).Count()
+	if err != nil {
+		return draft, err
+	}
+	if n >= MaxDrafts {
+		return draft, TooManyDrafts
+	}
+	draft.ID = bson.NewObjectId()
+	draft.Created = draft.Updated
+	err = d.Mgo().C("drafts").Insert(&draft)
+	return draft, err
+}
+
+// Delete user draft.
+func Delete(d deps, userID, id bson.ObjectId) error {
+	err := d.Mgo().C("drafts").Remove(bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return DraftNotFound
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package emojis
+
+import (
+	"errors"
+	"sync"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var EmojiNotFound = errors.New("Emoji has not been found by given criteria.")
+
+var (
+	cacheMu sync.RWMutex
+	cache   Emojis
+)
+
+// FindList of usable site emojis.
+func FindList(d deps) (list Emojis, err error) {
+	list, err = FindAll(d)
+	if err != nil {
+		return
+	}
+	list = list.Active()
+	return
+}
+
+// FindAll emojis including deleted ones (their tags may still live in content).
+// Results are kept in memory until emojis change.
+func FindAll(d deps) (list Emojis, err error) {
+	cacheMu.RLock()
+	list = cache
+	cacheMu.RUnlock()
+	if list != nil {
+		return
+	}
+	list = Emojis{}
+	err = d.Mgo().C("emojis").Find(nil).Sort("shortcode").All(&list)
+	if err != nil {
+		return
+	}
+	cacheMu.Lock()
+	cache = list
+	cacheMu.Unlock()
+	return
+}
+
+func FindId(d deps, id bson.ObjectId) (e Emoji, err error) {
+	err = d.Mgo().C("emojis").Find(bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}).One(&e)
+	if err != nil {
+		err = EmojiNotFound
+	}
+	return
+}
+
+func FindShortcode(d deps, code string) (e Emoji, err error) {
+	err = d.Mgo().C("emojis").Find(bson.M{
+		"shortcode":  code,
+		"deleted_at": bson.M{"$exists": false},
+	}).One(&e)
+	if err != nil {
+		err = EmojiNotFound
+	}
+	return
+}
+
+func invalidate() {
+	cacheMu.Lock()
+	cache = nil
+	cacheMu.Unlock()
+}
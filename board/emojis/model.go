@@ -0,0 +1,74 @@
+package emojis
+
+import (
+	"regexp"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var shortcodeRegex, _ = regexp.Compile(`^[a-z0-9_\-]{2,32}$`)
+
+// Emoji uploaded by site admins.
+type Emoji struct {
+	ID         bson.ObjectId   `bson:"_id,omitempty" json:"id"`
+	Shortcode  string          `bson:"shortcode" json:"shortcode"`
+	AssetID    bson.ObjectId   `bson:"asset_id" json:"-"`
+	URL        string          `bson:"url" json:"url"`
+	Categories []bson.ObjectId `bson:"categories,omitempty" json:"categories,omitempty"`
+	UserID     bson.ObjectId   `bson:"user_id" json:"-"`
+	Created    time.Time       `bson:"created_at" json:"created_at"`
+	Updated    time.Time       `bson:"updated_at" json:"updated_at"`
+	Deleted    *time.Time      `bson:"deleted_at,omitempty" json:"-"`
+}
+
+// AllowedIn checks whether the emoji can be used within given category.
+// Restricted emojis are never allowed outside categories (chat, profiles).
+func (e Emoji) AllowedIn(category bson.ObjectId) bool {
+	if len(e.Categories) == 0 {
+		return true
+	}
+	for _, id := range e.Categories {
+		if id == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Emojis list.
+type Emojis []Emoji
+
+// Active emojis only, leaving out deleted ones.
+func (list Emojis) Active() Emojis {
+	active := Emojis{}
+	for _, e := range list {
+		if e.Deleted == nil {
+			active = append(active, e)
+		}
+	}
+	return active
+}
+
+// ByShortcode hashmap.
+func (list Emojis) ByShortcode() map[string]Emoji {
+	m := make(map[string]Emoji, len(list))
+	for _, e := range list {
+		m[e.Shortcode] = e
+	}
+	return m
+}
+
+// ByID hashmap.
+func (list Emojis) ByID() map[bson.ObjectId]Emoji {
+	m := make(map[bson.ObjectId]Emoji, len(list))
+	for _, e := range list {
+		m[e.ID] = e
+	}
+	return m
+}
+
+// ValidShortcode checks shortcode format (lowercase letters, numbers, dashes and underscores).
+func ValidShortcode(code string) bool {
+	return shortcodeRegex.MatchString(code)
+}
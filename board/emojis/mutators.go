@@ -0,0 +1,80 @@
+package emojis
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/tryanzu/core/board/assets"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	InvalidShortcode = errors.New("Invalid shortcode, use 2 to 32 lowercase letters, numbers, dashes or underscores.")
+	ShortcodeTaken   = errors.New("Shortcode is already in use by another emoji.")
+)
+
+// Upload hosts the emoji image and saves it under given shortcode.
+func Upload(d deps, userID bson.ObjectId, code, filename string, data []byte, categories []bson.ObjectId) (e Emoji, err error) {
+	if ValidShortcode(code) == false {
+		err = InvalidShortcode
+		return
+	}
+	if _, err = FindShortcode(d, code); err == nil {
+		err = ShortcodeTaken
+		return
+	}
+	asset, err := assets.FromBytes(d, "emoji", data, filepath.Ext(filename))
+	if err != nil {
+		return
+	}
+	e = Emoji{
+		ID:         bson.NewObjectId(),
+		Shortcode:  code,
+		AssetID:    asset.ID,
+		URL:        asset.URL(),
+		Categories: categories,
+		UserID:     userID,
+		Created:    time.Now(),
+		Updated:    time.Now(),
+	}
+	err = d.Mgo().C("emojis").Insert(&e)
+	if err != nil {
+		return
+	}
+	invalidate()
+	return
+}
+
+// UpdateEmoji shortcode and category restrictions.
+func UpdateEmoji(d deps, e Emoji, code string, categories []bson.ObjectId) (Emoji, error) {
+	if ValidShortcode(code) == false {
+		return e, InvalidShortcode
+	}
+	if taken, err := FindShortcode(d, code); err == nil && taken.ID != e.ID {
+		return e, ShortcodeTaken
+	}
+	e.Shortcode = code
+	e.Categories = categories
+	e.Updated = time.Now()
+	err := d.Mgo().C("emojis").UpdateId(e.ID, bson.M{"$set": bson.M{
+		"shortcode":  e.Shortcode,
+		"categories": e.Categories,
+		"updated_at": e.Updated,
+	}})
+	if err != nil {
+		return e, err
+	}
+	invalidate()
+	return e, nil
+}
+
+// Delete emoji. Existing tags in content fall back to its shortcode.
+func Delete(d deps, e Emoji) error {
+	err := d.Mgo().C("emojis").UpdateId(e.ID, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	if err != nil {
+		return err
+	}
+	invalidate()
+	return nil
+}
@@ -10,7 +10,7 @@ import (
 
 type auditM struct {
 	ID        bson.ObjectId `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID    bson.ObjectId `bson:"user_id" json:"user_id"`
+	UserID    bson.ObjectId `bson:"user_id,omitempty" json:"user_id,omitempty"`
 	Related   string        `bson:"related" json:"related"`
 	RelatedID bson.ObjectId `bson:"related_id" json:"related_id"`
 	Reason    string        `bson:"reason" json:"reason"`
@@ -2,10 +2,14 @@ package events
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/tryanzu/core/board/categories"
 	"github.com/tryanzu/core/board/comments"
 	notify "github.com/tryanzu/core/board/notifications"
 	post "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/relations"
+	"github.com/tryanzu/core/board/trash"
 	"github.com/tryanzu/core/board/votes"
 	"github.com/tryanzu/core/core/config"
 	pool "github.com/tryanzu/core/core/events"
@@ -29,6 +33,10 @@ func commentsEvents() {
 			On:      pool.COMMENT_UPDATE,
 			Handler: onCommentUpdate,
 		},
+		{
+			On:      pool.COMMENT_RESTORED,
+			Handler: onCommentRestore,
+		},
 		{
 			On:      pool.VOTE,
 			Handler: onVote,
@@ -40,8 +48,10 @@ func commentsEvents() {
 
 func onVote(e pool.Event) error {
 	var (
-		err    error
-		userID bson.ObjectId
+		err      error
+		userID   bson.ObjectId
+		postID   bson.ObjectId
+		category bson.ObjectId
 	)
 	vote := e.Params["vote"].(votes.Vote)
 	field := vote.DbField()
@@ -51,10 +61,6 @@ func onVote(e pool.Event) error {
 	if vote.Deleted != nil {
 		value = -1
 	}
-	factor := 1
-	if vote.Deleted != nil {
-		factor = -1
-	}
 	switch vote.Type {
 	case "comment":
 		err = deps.Container.Mgo().C("comments").UpdateId(vote.RelatedID, bson.M{"$inc": bson.M{field: value}})
@@ -67,8 +73,12 @@ func onVote(e pool.Event) error {
 		if err != nil {
 			return err
 		}
+		p, err := post.FindId(deps.Container, comment.RelatedPost())
+		if err != nil {
+			return err
+		}
 		userID = comment.UserId
-		factor = factor * 4
+		postID, category = p.Id, p.Category
 	case "post":
 		err = deps.Container.Mgo().C("posts").UpdateId(vote.RelatedID, bson.M{"$inc": bson.M{field: value}})
 		if err != nil {
@@ -80,41 +90,75 @@ func onVote(e pool.Event) error {
 			return err
 		}
 		userID = post.UserId
-		factor = factor * 2
+		postID, category = post.Id, post.Category
 	}
 
-	// No gamification for eventual votes from comment's author
-	if vote.UserID == userID {
-		return nil
+	// Attribute the vote so reaction stats can be aggregated, then drop the stale ones.
+	vote, err = votes.Attribute(deps.Container, vote, userID, postID, category)
+	if err != nil {
+		return err
 	}
-	rules := config.C.Rules()
-	if rule, exists := rules.Reactions[vote.Value]; exists {
-		rewards, err := rule.Rewards()
+	err = votes.InvalidateStats(deps.Container, vote)
+	if err != nil {
+		return err
+	}
+
+	if vote.Type == "post" {
+		err = post.SyncRanks(deps.Container, []bson.ObjectId{vote.RelatedID})
 		if err != nil {
 			return err
 		}
-		if rewards.Provider > 0 {
-			err = gaming.IncreaseUserSwords(deps.Container, vote.UserID, int(rewards.Provider))
-		}
+	}
 
-		if rewards.Receiver > 0 {
-			err = gaming.IncreaseUserSwords(deps.Container, userID, int(rewards.Receiver))
-		}
+	// No gamification for eventual votes from comment's author
+	if vote.UserID == userID {
+		return nil
 	}
 
-	switch vote.Value {
-	case "concise", "useful":
-		err = pipeErr(
-			//gaming.IncreaseUserSwords(deps.Container, vote.UserID, 1*factor),
-			gaming.IncreaseUserSwords(deps.Container, userID, 2*factor),
-		)
-	case "offtopic", "wordy":
-		err = pipeErr(
-			gaming.IncreaseUserSwords(deps.Container, userID, 1*factor/2*-1),
+	// Retracted votes take back exactly what they awarded. Handlers run
+	// concurrently, so the awarded gains are read from the vote as they
+	// get cleared instead of trusting the copy this event carries.
+	if vote.Deleted != nil {
+		awarded, err := votes.Reclaim(deps.Container, vote.ID)
+		if err != nil {
+			return err
+		}
+		return pipeErr(
+			gaming.Reward(deps.Container, vote.UserID, awarded.Provider.Neg()),
+			gaming.Reward(deps.Container, userID, awarded.Receiver.Neg()),
 		)
 	}
 
-	return err
+	c, err := categories.FindId(deps.Container, category)
+	if err != nil {
+		return err
+	}
+	rule, exists := config.C.Rules().Reaction(vote.Value, c.ReactSet)
+	if !exists {
+		return nil
+	}
+	effect, err := rule.Effect(vote.Type, vote.Value)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	provider, err := votes.Earn(deps.Container, vote.UserID, vote.Value, effect.Provider, effect.Cap, now)
+	if err != nil {
+		return err
+	}
+	receiver, err := votes.Earn(deps.Container, userID, vote.Value, effect.Receiver, effect.Cap, now)
+	if err != nil {
+		return err
+	}
+	// Votes toggled again meanwhile are settled by their own events.
+	awarded, err := votes.Award(deps.Container, vote, provider, receiver)
+	if err != nil || !awarded {
+		return err
+	}
+	return pipeErr(
+		gaming.Reward(deps.Container, vote.UserID, provider),
+		gaming.Reward(deps.Container, userID, receiver),
+	)
 }
 
 func onCommentDelete(e pool.Event) error {
@@ -141,9 +185,39 @@ func onCommentDelete(e pool.Event) error {
 
 	if e.Sign != nil {
 		audit("comment", cid, "delete", *e.Sign)
+
+		comment, err := comments.FindId(deps.Container, cid)
+		if err != nil {
+			return err
+		}
+		p, err := post.FindId(deps.Container, pid)
+		if err != nil {
+			return err
+		}
+		err = trash.Put(deps.Container, trash.FromComment(comment, p, e.Sign.UserID, e.Sign.Reason))
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return post.SyncRanks(deps.Container, []bson.ObjectId{pid})
+}
+
+func onCommentRestore(e pool.Event) error {
+	cid := e.Params["id"].(bson.ObjectId)
+	pid := e.Params["post_id"].(bson.ObjectId)
+
+	notify.Transmit <- notify.Socket{
+		Chan:   "post",
+		Action: pid.Hex(),
+		Params: map[string]interface{}{
+			"fire": "comment-restored",
+			"id":   cid.Hex(),
+		},
+	}
+
+	audit("comment", cid, "restore", *e.Sign)
+	return post.SyncRanks(deps.Container, []bson.ObjectId{pid})
 }
 
 func onPostComment(e pool.Event) error {
@@ -152,6 +226,9 @@ func onPostComment(e pool.Event) error {
 		return err
 	}
 
+	// Users notified directly about the comment, thread followers get the rest.
+	notified := map[bson.ObjectId]bool{comment.UserId: true}
+
 	if comment.ReplyType == "comment" {
 		ref, err := comments.FindId(deps.Container, comment.RelatedID())
 		if err != nil {
@@ -159,6 +236,7 @@ func onPostComment(e pool.Event) error {
 		}
 
 		if ref.UserId != comment.UserId {
+			notified[ref.UserId] = true
 			notify.Database <- notify.Notification{
 				UserId:    ref.UserId,
 				Type:      "comment",
@@ -175,6 +253,7 @@ func onPostComment(e pool.Event) error {
 		}
 
 		if post.UserId != comment.UserId {
+			notified[post.UserId] = true
 			notify.Database <- notify.Notification{
 				UserId:    post.UserId,
 				Type:      "comment",
@@ -209,7 +288,29 @@ func onPostComment(e pool.Event) error {
 		}
 	}
 
-	return nil
+	pid := comment.RelatedPost()
+	followers, err := relations.FindUsers(deps.Container, relations.Follow, "post", pid)
+	if err != nil {
+		return err
+	}
+	for _, id := range followers {
+		if notified[id] {
+			continue
+		}
+		notify.Database <- notify.Notification{
+			UserId:    id,
+			Type:      "thread",
+			RelatedId: pid,
+			Users:     []bson.ObjectId{comment.UserId},
+		}
+	}
+
+	err = relations.AutoFollow(deps.Container, comment.UserId, pid)
+	if err != nil {
+		return err
+	}
+
+	return post.SyncRanks(deps.Container, []bson.ObjectId{pid})
 }
 
 func onCommentUpdate(e pool.Event) error {
@@ -5,8 +5,12 @@ import (
 	"log"
 	"time"
 
+	"github.com/tryanzu/core/board/comments"
 	"github.com/tryanzu/core/board/flags"
+	notify "github.com/tryanzu/core/board/notifications"
+	posts "github.com/tryanzu/core/board/posts"
 	"github.com/tryanzu/core/board/realtime"
+	"github.com/tryanzu/core/core/common"
 	ev "github.com/tryanzu/core/core/events"
 	"github.com/tryanzu/core/core/user"
 	"github.com/tryanzu/core/deps"
@@ -26,6 +30,11 @@ func flagHandlers() {
 			if err != nil {
 				return ErrInvalidIDRef
 			}
+			if f.RelatedID != nil && (f.RelatedTo == "post" || f.RelatedTo == "comment") {
+				if err := attributeFlag(f); err != nil {
+					return err
+				}
+			}
 			if f.Reason == "spam" && f.RelatedTo == "chat" {
 				usr, err := user.FindId(deps.Container, f.UserID)
 				if err != nil {
@@ -47,6 +56,30 @@ func flagHandlers() {
 			return nil
 		},
 	}
+	ev.On <- ev.EventHandler{
+		On: ev.FLAGS_RESOLVED,
+		Handler: func(e ev.Event) error {
+			related := e.Params["related"].(string)
+			id := e.Params["id"].(bson.ObjectId)
+			status := e.Params["status"].(string)
+			audit(related, id, "flags-"+status, *e.Sign)
+
+			// Reporters hear back once per target, however many times they flagged it.
+			list, err := flags.FindList(deps.Container, common.WithinID(e.Params["flags"].([]bson.ObjectId)))
+			if err != nil {
+				return err
+			}
+			for _, f := range list.Reported() {
+				notify.Database <- notify.Notification{
+					UserId:    f.UserID,
+					Type:      "flag",
+					RelatedId: f.ID,
+					Users:     []bson.ObjectId{},
+				}
+			}
+			return nil
+		},
+	}
 	ev.On <- ev.EventHandler{
 		On: ev.NEW_BAN,
 		Handler: func(e ev.Event) error {
@@ -72,6 +105,24 @@ func flagHandlers() {
 	}
 }
 
+// attributeFlag to the post and category of the flagged post or comment.
+func attributeFlag(f flags.Flag) error {
+	postID := *f.RelatedID
+	if f.RelatedTo == "comment" {
+		comment, err := comments.FindId(deps.Container, postID)
+		if err != nil {
+			return err
+		}
+		postID = comment.RelatedPost()
+	}
+	post, err := posts.FindId(deps.Container, postID)
+	if err != nil {
+		return err
+	}
+	_, err = flags.Attribute(deps.Container, f, post.Id, post.Category)
+	return err
+}
+
 func banLog(ban user.Ban, user user.User) realtime.M {
 	diff := ban.Until.Sub(ban.Created).Truncate(time.Second)
 	return realtime.M{
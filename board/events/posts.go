@@ -4,6 +4,10 @@ import (
 	"github.com/tryanzu/core/board/comments"
 	notify "github.com/tryanzu/core/board/notifications"
 	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/relations"
+	"github.com/tryanzu/core/board/search"
+	"github.com/tryanzu/core/board/threads"
+	"github.com/tryanzu/core/board/trash"
 	ev "github.com/tryanzu/core/core/events"
 	"github.com/tryanzu/core/deps"
 	"gopkg.in/mgo.v2/bson"
@@ -31,7 +35,8 @@ func postsEvents() {
 				},
 			}
 
-			return nil
+			search.IndexPost(post)
+			return relations.AutoFollow(deps.Container, post.UserId, post.Id)
 		},
 	}
 
@@ -44,7 +49,10 @@ func postsEvents() {
 			}
 
 			err = posts.TrackView(deps.Container, post.Id, e.Sign.UserID)
-			return err
+			if err != nil {
+				return err
+			}
+			return posts.SyncRanks(deps.Container, []bson.ObjectId{post.Id})
 		},
 	}
 
@@ -67,8 +75,74 @@ func postsEvents() {
 				audit("post", pid, "delete", *e.Sign)
 			}
 
-			err := comments.DeletePostComments(deps.Container, pid)
-			return err
+			search.UnindexPost(pid)
+
+			n, err := comments.DeletePostComments(deps.Container, pid)
+			if err != nil {
+				return err
+			}
+			post, err := posts.FindId(deps.Container, pid)
+			if err != nil {
+				return err
+			}
+			if e.Sign != nil {
+				err = trash.Put(deps.Container, trash.FromPost(post, e.Sign.UserID, e.Sign.Reason, n))
+				if err != nil {
+					return err
+				}
+			}
+			err = threads.CountUnread(deps.Container, post, -1)
+			if err != nil {
+				return err
+			}
+			return posts.SyncRanks(deps.Container, []bson.ObjectId{pid})
+		},
+	}
+
+	ev.On <- ev.EventHandler{
+		On: ev.POST_RESTORED,
+		Handler: func(e ev.Event) error {
+			pid := e.Params["id"].(bson.ObjectId)
+			post, err := posts.FindId(deps.Container, pid)
+			if err != nil {
+				return err
+			}
+
+			notify.Transmit <- notify.Socket{
+				Chan:   "feed",
+				Action: "action",
+				Params: map[string]interface{}{
+					"fire":     "restored-post",
+					"category": post.Category.Hex(),
+					"id":       pid.Hex(),
+				},
+			}
+
+			audit("post", pid, "restore", *e.Sign)
+			search.IndexPost(post)
+			err = threads.CountUnread(deps.Container, post, 1)
+			if err != nil {
+				return err
+			}
+			return posts.SyncRanks(deps.Container, []bson.ObjectId{pid})
+		},
+	}
+
+	ev.On <- ev.EventHandler{
+		On: ev.POST_UPDATED,
+		Handler: func(e ev.Event) error {
+			pid := e.Params["id"].(bson.ObjectId)
+			if e.Sign != nil {
+				audit("post", pid, e.Params["action"].(string), *e.Sign)
+			}
+
+			// Keep suggestions in sync with edited, moved or merged posts.
+			post, err := posts.FindId(deps.Container, pid)
+			if err != nil {
+				return err
+			}
+			search.IndexPost(post)
+			return nil
 		},
 	}
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/tryanzu/core/core/common"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -30,6 +31,82 @@ func FindOne(d deps, related string, relatedID, userID bson.ObjectId) (f Flag, e
 	return
 }
 
+// FindList of flags within given scopes.
+func FindList(d deps, scopes ...common.Scope) (list Flags, err error) {
+	err = d.Mgo().C("flags").Find(common.ByScope(scopes...)).All(&list)
+	return
+}
+
+// FindTargets flagged by users matching the filter, grouping their flags.
+// Pending flags over posts and comments are listed unless another status or
+// kind is given.
+func FindTargets(d deps, f Filter) (list Targets, err error) {
+	list = Targets{}
+	err = d.Mgo().C("flags").Pipe(targetsPipeline(f)).All(&list)
+	return
+}
+
+func targetsPipeline(f Filter) []bson.M {
+	criteria := bson.M{
+		"status":     PENDING,
+		"related_to": bson.M{"$in": []string{"post", "comment"}},
+		"deleted_at": bson.M{"$exists": false},
+	}
+	if len(f.Status) > 0 {
+		criteria["status"] = f.Status
+	}
+	if len(f.Reason) > 0 {
+		criteria["reason"] = f.Reason
+	}
+	if len(f.RelatedTo) > 0 {
+		criteria["related_to"] = f.RelatedTo
+	}
+	if f.Category != nil {
+		criteria["category"] = *f.Category
+	}
+	return []bson.M{
+		{"$match": criteria},
+		{"$sort": bson.M{"created_at": 1}},
+		{"$group": bson.M{
+			"_id":      bson.M{"related_to": "$related_to", "related_id": "$related_id"},
+			"post_id":  bson.M{"$last": "$post_id"},
+			"category": bson.M{"$last": "$category"},
+			"count":    bson.M{"$sum": 1},
+			"reasons":  bson.M{"$addToSet": "$reason"},
+			"flags":    bson.M{"$push": "$$ROOT"},
+			"last_at":  bson.M{"$last": "$created_at"},
+		}},
+		{"$project": bson.M{
+			"_id":        0,
+			"related_to": "$_id.related_to",
+			"related_id": "$_id.related_id",
+			"post_id":    1,
+			"category":   1,
+			"count":      1,
+			"reasons":    1,
+			"flags":      1,
+			"last_at":    1,
+		}},
+		{"$sort": bson.D{{Name: "count", Value: -1}, {Name: "last_at", Value: -1}}},
+		{"$skip": f.Offset},
+		{"$limit": f.Limit},
+	}
+}
+
+// FindTarget flags still open, pending or escalated, over a post or comment.
+func FindTarget(d deps, related string, id bson.ObjectId) (list Flags, err error) {
+	err = d.Mgo().C("flags").Find(bson.M{
+		"related_to": related,
+		"related_id": id,
+		"status":     bson.M{"$in": []status{PENDING, ESCALATED}},
+		"deleted_at": bson.M{"$exists": false},
+	}).Sort("created_at").All(&list)
+	if err == nil && len(list) == 0 {
+		err = FlagNotFound
+	}
+	return
+}
+
 func Count(d deps, q bson.M) int {
 	n, err := d.Mgo().C("flags").Find(q).Count()
 	if err != nil {
@@ -0,0 +1,29 @@
+package flags
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestTargetsPipeline(t *testing.T) {
+	Convey("Flags queue", t, func() {
+		Convey("Groups flags by their target", func() {
+			pipeline := targetsPipeline(Filter{Limit: 20})
+			So(pipeline[2]["$group"].(bson.M)["_id"], ShouldResemble, bson.M{"related_to": "$related_to", "related_id": "$related_id"})
+		})
+
+		Convey("Lists pending posts and comments flags by default", func() {
+			criteria := targetsPipeline(Filter{Limit: 20})[0]["$match"].(bson.M)
+			So(criteria["status"], ShouldEqual, PENDING)
+			So(criteria["related_to"], ShouldResemble, bson.M{"$in": []string{"post", "comment"}})
+		})
+
+		Convey("Lists other kinds and statuses when asked", func() {
+			criteria := targetsPipeline(Filter{Status: "escalated", RelatedTo: "chat", Limit: 20})[0]["$match"].(bson.M)
+			So(criteria["status"], ShouldEqual, "escalated")
+			So(criteria["related_to"], ShouldEqual, "chat")
+		})
+	})
+}
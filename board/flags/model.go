@@ -1,6 +1,7 @@
 package flags
 
 import (
+	"html"
 	"time"
 
 	"gopkg.in/mgo.v2/bson"
@@ -9,20 +10,133 @@ import (
 type status string
 
 const (
-	PENDING  status = "pending"
-	REJECTED status = "rejected"
+	PENDING   status = "pending"
+	REJECTED  status = "rejected"
+	ACCEPTED  status = "accepted"
+	ESCALATED status = "escalated"
 )
 
-// Flag represents a report sent by a user flagging a post/comment.
+// Statuses flags can be resolved with.
+var Statuses = map[string]status{
+	"accept":   ACCEPTED,
+	"reject":   REJECTED,
+	"escalate": ESCALATED,
+}
+
+// Flag represents a report sent by a user flagging a post/comment. System
+// flags are sent on behalf of the content author, nobody reported it.
 type Flag struct {
-	ID        bson.ObjectId  `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID    bson.ObjectId  `bson:"user_id" json:"user_id"`
+	ID         bson.ObjectId  `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID     bson.ObjectId  `bson:"user_id" json:"user_id"`
+	RelatedTo  string         `bson:"related_to" json:"related_to"`
+	RelatedID  *bson.ObjectId `bson:"related_id" json:"related_id,omitempty"`
+	PostID     *bson.ObjectId `bson:"post_id,omitempty" json:"post_id,omitempty"`
+	Category   *bson.ObjectId `bson:"category,omitempty" json:"category,omitempty"`
+	Content    string         `bson:"content" json:"content"`
+	Status     status         `bson:"status" json:"status"`
+	Reason     string         `bson:"reason" json:"reason"`
+	System     bool           `bson:"system,omitempty" json:"system,omitempty"`
+	Note       string         `bson:"note,omitempty" json:"note,omitempty"`
+	Actions    []string       `bson:"actions,omitempty" json:"actions,omitempty"`
+	ResolvedBy *bson.ObjectId `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	Resolved   *time.Time     `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+	Created    time.Time      `bson:"created_at" json:"created_at"`
+	Updated    time.Time      `bson:"updated_at" json:"updated_at"`
+	Deleted    *time.Time     `bson:"deleted_at,omitempty" json:"-"`
+}
+
+// Flags list.
+type Flags []Flag
+
+// Map of flags by id.
+func (list Flags) Map() map[bson.ObjectId]Flag {
+	m := make(map[bson.ObjectId]Flag, len(list))
+	for _, f := range list {
+		m[f.ID] = f
+	}
+	return m
+}
+
+// PostIDs of the posts flagged or holding flagged comments.
+func (list Flags) PostIDs() []bson.ObjectId {
+	ids := []bson.ObjectId{}
+	for _, f := range list {
+		if f.PostID != nil {
+			ids = append(ids, *f.PostID)
+		}
+	}
+	return ids
+}
+
+// Reported flags, the first one of every reporter. System flags have no
+// reporter so they are left out.
+func (list Flags) Reported() Flags {
+	reported := Flags{}
+	seen := map[bson.ObjectId]bool{}
+	for _, f := range list {
+		if f.System || seen[f.UserID] {
+			continue
+		}
+		seen[f.UserID] = true
+		reported = append(reported, f)
+	}
+	return reported
+}
+
+// resolved copies of the flags with given status. The note and the actions
+// taken along are kept on every flag for the record.
+func (list Flags) resolved(s status, note string, actions []string, by bson.ObjectId, now time.Time) Flags {
+	resolved := make(Flags, len(list))
+	for i, f := range list {
+		f.Status = s
+		f.ResolvedBy = &by
+		f.Resolved = &now
+		f.Updated = now
+		if len(note) > 0 {
+			f.Note = html.EscapeString(note)
+		}
+		if len(actions) > 0 {
+			f.Actions = actions
+		}
+		resolved[i] = f
+	}
+	return resolved
+}
+
+// Target flagged by users along with its flags, the queue moderators work on.
+// Content holds the flagged post or comment when it could be found.
+type Target struct {
 	RelatedTo string         `bson:"related_to" json:"related_to"`
-	RelatedID *bson.ObjectId `bson:"related_id" json:"related_id,omitempty"`
-	Content   string         `bson:"content" json:"content"`
-	Status    status         `bson:"status" json:"status"`
-	Reason    string         `bson:"reason" json:"reason"`
-	Created   time.Time      `bson:"created_at" json:"created_at"`
-	Updated   time.Time      `bson:"updated_at" json:"updated_at"`
-	Deleted   *time.Time     `bson:"deleted_at,omitempty" json:"-"`
+	RelatedID bson.ObjectId  `bson:"related_id" json:"related_id"`
+	PostID    *bson.ObjectId `bson:"post_id" json:"post_id,omitempty"`
+	Category  *bson.ObjectId `bson:"category" json:"category,omitempty"`
+	Count     int            `bson:"count" json:"count"`
+	Reasons   []string       `bson:"reasons" json:"reasons"`
+	Flags     Flags          `bson:"flags" json:"flags"`
+	Last      time.Time      `bson:"last_at" json:"last_at"`
+	Content   interface{}    `bson:"-" json:"content"`
+}
+
+// Targets list.
+type Targets []Target
+
+// Filter of the flags queue, most flagged targets first.
+type Filter struct {
+	Status    string
+	Reason    string
+	RelatedTo string
+	Category  *bson.ObjectId
+	Offset    int
+	Limit     int
+}
+
+// IDs of the targets related to given kind.
+func (list Targets) IDs(related string) []bson.ObjectId {
+	ids := []bson.ObjectId{}
+	for _, t := range list {
+		if t.RelatedTo == related {
+			ids = append(ids, t.RelatedID)
+		}
+	}
+	return ids
 }
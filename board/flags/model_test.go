@@ -0,0 +1,42 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestResolved(t *testing.T) {
+	Convey("Resolved flags", t, func() {
+		a, b, author, mod := bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId()
+		list := Flags{
+			{ID: bson.NewObjectId(), UserID: a, Status: PENDING},
+			{ID: bson.NewObjectId(), UserID: author, Status: PENDING, System: true},
+			{ID: bson.NewObjectId(), UserID: b, Status: ESCALATED},
+			{ID: bson.NewObjectId(), UserID: a, Status: PENDING},
+		}
+
+		Convey("Keep the status, note and actions on every flag", func() {
+			now := time.Now()
+			resolved := list.resolved(Statuses["accept"], "<b>spam</b>", []string{"delete"}, mod, now)
+			So(len(resolved), ShouldEqual, len(list))
+			for _, f := range resolved {
+				So(f.Status, ShouldEqual, ACCEPTED)
+				So(*f.ResolvedBy, ShouldEqual, mod)
+				So(*f.Resolved, ShouldEqual, now)
+				So(f.Note, ShouldEqual, "&lt;b&gt;spam&lt;/b&gt;")
+				So(f.Actions, ShouldResemble, []string{"delete"})
+			}
+			So(list[0].Status, ShouldEqual, PENDING)
+		})
+
+		Convey("Notify every reporter once, system flags have none", func() {
+			reported := list.Reported()
+			So(len(reported), ShouldEqual, 2)
+			So(reported[0].ID, ShouldEqual, list[0].ID)
+			So(reported[1].ID, ShouldEqual, list[2].ID)
+		})
+	})
+}
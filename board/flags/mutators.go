@@ -28,3 +28,37 @@ func UpsertFlag(d deps, f Flag) (flag Flag, err error) {
 	flag = f
 	return
 }
+
+// Attribute a flag to the post and category of the flagged item, so the
+// queue can be filtered without looking them up.
+func Attribute(d deps, f Flag, postID, category bson.ObjectId) (Flag, error) {
+	f.PostID = &postID
+	f.Category = &category
+	err := d.Mgo().C("flags").UpdateId(f.ID, bson.M{"$set": bson.M{
+		"post_id":  postID,
+		"category": category,
+	}})
+	return f, err
+}
+
+// Resolve open flags with given status. The note and the actions taken along
+// are kept on every flag for the record.
+func Resolve(d deps, list Flags, s status, note string, actions []string, by bson.ObjectId) (Flags, error) {
+	ids := make([]bson.ObjectId, len(list))
+	for i, f := range list {
+		ids[i] = f.ID
+	}
+	now := time.Now()
+	set := bson.M{"status": s, "resolved_by": by, "resolved_at": now, "updated_at": now}
+	if len(note) > 0 {
+		set["note"] = html.EscapeString(note)
+	}
+	if len(actions) > 0 {
+		set["actions"] = actions
+	}
+	_, err := d.Mgo().C("flags").UpdateAll(bson.M{"_id": bson.M{"$in": ids}}, bson.M{"$set": set})
+	if err != nil {
+		return list, err
+	}
+	return list.resolved(s, note, actions, by, now), nil
+}
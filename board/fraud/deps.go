@@ -0,0 +1,13 @@
+package fraud
+
+import (
+	"github.com/siddontang/ledisdb/ledis"
+	"github.com/tryanzu/core/board/legacy/model"
+	"gopkg.in/mgo.v2"
+)
+
+type deps interface {
+	Mgo() *mgo.Database
+	LedisDB() *ledis.DB
+	GamingConfig() *model.GamingRules
+}
@@ -0,0 +1,287 @@
+package fraud
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Detection thresholds, votes older than the window are not analyzed.
+const (
+	window         = 30 * 24 * time.Hour
+	ringMinVotes   = 5
+	ringMinShare   = 0.25
+	burstMinVotes  = 3
+	burstMinUsers  = 2
+	newAccountAge  = 14 * 24 * time.Hour
+	singleMinVotes = 5
+)
+
+type burst struct {
+	Key struct {
+		Target bson.ObjectId `bson:"target"`
+	} `bson:"_id"`
+	Users []bson.ObjectId `bson:"users"`
+	Votes []bson.ObjectId `bson:"votes"`
+}
+
+type pair struct {
+	Key struct {
+		From bson.ObjectId `bson:"from"`
+		To   bson.ObjectId `bson:"to"`
+	} `bson:"_id"`
+	Count int `bson:"count"`
+}
+
+// Detect vote manipulation patterns over recent votes. Pending reports
+// saved by this run are returned.
+func Detect(d deps, now time.Time) (list Reports, err error) {
+	list = Reports{}
+	for _, detect := range []func(deps, time.Time) (Reports, error){detectRings, detectBursts, detectSingleTarget} {
+		var found Reports
+		found, err = detect(d, now)
+		if err != nil {
+			return
+		}
+		for _, r := range found {
+			var saved bool
+			r, saved, err = save(d, r, now)
+			if err != nil {
+				return
+			}
+			if saved {
+				list = append(list, r)
+			}
+		}
+	}
+	return
+}
+
+// detectRings of users mostly voting for each other.
+func detectRings(d deps, now time.Time) (list Reports, err error) {
+	since := now.Add(-window)
+	var pairs []pair
+	err = d.Mgo().C("votes").Pipe([]bson.M{
+		{"$match": active(bson.M{"owner_id": bson.M{"$exists": true}}, since)},
+		{"$group": bson.M{
+			"_id":   bson.M{"from": "$user_id", "to": "$owner_id"},
+			"count": bson.M{"$sum": 1},
+		}},
+	}).All(&pairs)
+	if err != nil {
+		return
+	}
+	for _, users := range rings(pairs, ringMinVotes, ringMinShare) {
+		var ids []bson.ObjectId
+		ids, err = voteIDs(d, active(bson.M{
+			"user_id":  bson.M{"$in": users},
+			"owner_id": bson.M{"$in": users},
+		}, since))
+		if err != nil {
+			return
+		}
+		list = append(list, Report{
+			Kind:  KindRing,
+			Key:   KindRing + ":" + joinIDs(users),
+			Users: users,
+			Votes: ids,
+		})
+	}
+	return
+}
+
+// detectBursts of votes for the same user from accounts sharing an address.
+func detectBursts(d deps, now time.Time) (list Reports, err error) {
+	since := now.Add(-window)
+	var addresses []struct {
+		Address string          `bson:"address"`
+		Users   []bson.ObjectId `bson:"users"`
+	}
+	err = d.Mgo().C("trusted_addresses").Find(bson.M{"users.1": bson.M{"$exists": true}}).All(&addresses)
+	if err != nil {
+		return
+	}
+	for _, a := range addresses {
+		var found []burst
+		err = d.Mgo().C("votes").Pipe([]bson.M{
+			{"$match": active(bson.M{"user_id": bson.M{"$in": a.Users}, "owner_id": bson.M{"$exists": true, "$nin": a.Users}}, since)},
+			{"$group": bson.M{
+				"_id": bson.M{
+					"target": "$owner_id",
+					"hour":   bson.M{"$dateToString": bson.M{"format": "%Y-%m-%dT%H", "date": "$created_at"}},
+				},
+				"users": bson.M{"$addToSet": "$user_id"},
+				"votes": bson.M{"$push": "$_id"},
+			}},
+		}).All(&found)
+		if err != nil {
+			return
+		}
+		list = append(list, bursts(a.Address, found, burstMinUsers, burstMinVotes)...)
+	}
+	return
+}
+
+// bursts of votes from accounts sharing an address worth reporting.
+func bursts(address string, found []burst, minUsers, minVotes int) (list Reports) {
+	for _, b := range found {
+		if len(b.Users) < minUsers || len(b.Votes) < minVotes {
+			continue
+		}
+		target := b.Key.Target
+		list = append(list, Report{
+			Kind:    KindSharedAddress,
+			Key:     KindSharedAddress + ":" + address + ":" + target.Hex(),
+			Users:   sortIDs(b.Users),
+			Target:  &target,
+			Address: address,
+			Votes:   b.Votes,
+		})
+	}
+	return
+}
+
+// detectSingleTarget finds new accounts whose votes all go to the same user.
+func detectSingleTarget(d deps, now time.Time) (list Reports, err error) {
+	since := now.Add(-window)
+	var accounts []struct {
+		ID bson.ObjectId `bson:"_id"`
+	}
+	err = d.Mgo().C("users").Find(bson.M{"created_at": bson.M{"$gte": now.Add(-newAccountAge)}}).Select(bson.M{"_id": 1}).All(&accounts)
+	if err != nil || len(accounts) == 0 {
+		return
+	}
+	ids := make([]bson.ObjectId, len(accounts))
+	for i, a := range accounts {
+		ids[i] = a.ID
+	}
+	var voters []struct {
+		ID      bson.ObjectId   `bson:"_id"`
+		Targets []bson.ObjectId `bson:"targets"`
+		Votes   []bson.ObjectId `bson:"votes"`
+	}
+	err = d.Mgo().C("votes").Pipe([]bson.M{
+		{"$match": active(bson.M{"user_id": bson.M{"$in": ids}, "owner_id": bson.M{"$exists": true}}, since)},
+		{"$group": bson.M{
+			"_id":     "$user_id",
+			"targets": bson.M{"$addToSet": "$owner_id"},
+			"votes":   bson.M{"$push": "$_id"},
+		}},
+	}).All(&voters)
+	if err != nil {
+		return
+	}
+
+	// Accounts voting for the same user end up in the same report.
+	index := map[bson.ObjectId]int{}
+	for _, v := range voters {
+		if len(v.Targets) != 1 || len(v.Votes) < singleMinVotes || v.Targets[0] == v.ID {
+			continue
+		}
+		target := v.Targets[0]
+		i, exists := index[target]
+		if !exists {
+			i = len(list)
+			index[target] = i
+			list = append(list, Report{
+				Kind:   KindSingleTarget,
+				Key:    KindSingleTarget + ":" + target.Hex(),
+				Target: &target,
+			})
+		}
+		list[i].Users = append(list[i].Users, v.ID)
+		list[i].Votes = append(list[i].Votes, v.Votes...)
+	}
+	return
+}
+
+// rings of users linked by reciprocal votes, each one giving the other at
+// least min votes and the given share of all the votes they gave.
+func rings(pairs []pair, min int, share float64) [][]bson.ObjectId {
+	given := map[bson.ObjectId]int{}
+	counts := map[[2]bson.ObjectId]int{}
+	for _, p := range pairs {
+		if p.Key.From == p.Key.To {
+			continue
+		}
+		given[p.Key.From] += p.Count
+		counts[[2]bson.ObjectId{p.Key.From, p.Key.To}] += p.Count
+	}
+	strong := func(from, to bson.ObjectId) bool {
+		n := counts[[2]bson.ObjectId{from, to}]
+		return n >= min && float64(n) >= share*float64(given[from])
+	}
+
+	// Union reciprocal pairs into rings.
+	parent := map[bson.ObjectId]bson.ObjectId{}
+	var find func(bson.ObjectId) bson.ObjectId
+	find = func(id bson.ObjectId) bson.ObjectId {
+		if p, exists := parent[id]; exists && p != id {
+			parent[id] = find(p)
+			return parent[id]
+		}
+		parent[id] = id
+		return id
+	}
+	for k := range counts {
+		if strong(k[0], k[1]) && strong(k[1], k[0]) {
+			parent[find(k[0])] = find(k[1])
+		}
+	}
+	members := map[bson.ObjectId][]bson.ObjectId{}
+	for id := range parent {
+		root := find(id)
+		members[root] = append(members[root], id)
+	}
+	list := [][]bson.ObjectId{}
+	for _, users := range members {
+		if len(users) > 1 {
+			list = append(list, sortIDs(users))
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i][0] < list[j][0]
+	})
+	return list
+}
+
+func active(criteria bson.M, since time.Time) bson.M {
+	criteria["type"] = bson.M{"$in": []string{"post", "comment"}}
+	criteria["deleted_at"] = bson.M{"$exists": false}
+	criteria["created_at"] = bson.M{"$gte": since}
+	return criteria
+}
+
+// voteIDs matching given criteria, leaving votes on their own items out.
+func voteIDs(d deps, criteria bson.M) ([]bson.ObjectId, error) {
+	var list []struct {
+		ID     bson.ObjectId `bson:"_id"`
+		UserID bson.ObjectId `bson:"user_id"`
+		Owner  bson.ObjectId `bson:"owner_id"`
+	}
+	err := d.Mgo().C("votes").Find(criteria).Select(bson.M{"_id": 1, "user_id": 1, "owner_id": 1}).All(&list)
+	ids := []bson.ObjectId{}
+	for _, v := range list {
+		if v.UserID != v.Owner {
+			ids = append(ids, v.ID)
+		}
+	}
+	return ids, err
+}
+
+func sortIDs(ids []bson.ObjectId) []bson.ObjectId {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+func joinIDs(ids []bson.ObjectId) string {
+	hex := make([]string, len(ids))
+	for i, id := range ids {
+		hex[i] = id.Hex()
+	}
+	return strings.Join(hex, ",")
+}
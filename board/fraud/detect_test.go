@@ -0,0 +1,58 @@
+package fraud
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestRings(t *testing.T) {
+	Convey("Users mostly voting for each other are linked into rings", t, func() {
+		a, b, c, x, y := bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId()
+		votes := func(from, to bson.ObjectId, n int) (p pair) {
+			p.Key.From, p.Key.To, p.Count = from, to, n
+			return
+		}
+		pairs := []pair{
+			votes(a, b, 6), votes(b, a, 5),
+			votes(b, c, 8), votes(c, b, 10),
+			// One-way fans and reciprocal but occasional voters are left alone.
+			votes(x, a, 20),
+			votes(x, y, 2), votes(y, x, 2),
+			// Own votes never link anyone.
+			votes(y, y, 30),
+		}
+		list := rings(pairs, 5, 0.25)
+		So(len(list), ShouldEqual, 1)
+		So(list[0], ShouldResemble, sortIDs([]bson.ObjectId{a, b, c}))
+
+		Convey("Unless their share of votes is too small", func() {
+			pairs = append(pairs, votes(a, y, 100))
+			list := rings(pairs, 5, 0.25)
+			So(list[0], ShouldResemble, sortIDs([]bson.ObjectId{b, c}))
+		})
+	})
+}
+
+func TestBursts(t *testing.T) {
+	Convey("Accounts sharing an address voting for the same user are reported", t, func() {
+		a, b, target := bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId()
+		votes := []bson.ObjectId{bson.NewObjectId(), bson.NewObjectId(), bson.NewObjectId()}
+		voted := func(users ...bson.ObjectId) (b burst) {
+			b.Key.Target, b.Users, b.Votes = target, users, votes
+			return
+		}
+		list := bursts("10.0.0.1", []burst{voted(b, a)}, 2, 3)
+		So(len(list), ShouldEqual, 1)
+		So(list[0].Kind, ShouldEqual, KindSharedAddress)
+		So(list[0].Users, ShouldResemble, sortIDs([]bson.ObjectId{a, b}))
+		So(*list[0].Target, ShouldEqual, target)
+		So(list[0].Address, ShouldEqual, "10.0.0.1")
+		So(list[0].Votes, ShouldResemble, votes)
+
+		Convey("Unless a single account of the address voted", func() {
+			So(bursts("10.0.0.1", []burst{voted(a)}, 2, 3), ShouldBeEmpty)
+		})
+	})
+}
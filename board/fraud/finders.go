@@ -0,0 +1,36 @@
+package fraud
+
+import (
+	"errors"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var ReportNotFound = errors.New("Report has not been found by given criteria.")
+
+// FindList of reports with given status and optionally kind, latest first.
+// Next points to the last report read when more could follow.
+func FindList(d deps, status, kind string, before *bson.ObjectId, limit int) (list Reports, next *bson.ObjectId, err error) {
+	list = Reports{}
+	criteria := bson.M{"status": status}
+	if len(kind) > 0 {
+		criteria["kind"] = kind
+	}
+	if before != nil {
+		criteria["_id"] = bson.M{"$lt": *before}
+	}
+	err = d.Mgo().C("vote_reports").Find(criteria).Sort("-_id").Limit(limit).All(&list)
+	if err == nil && len(list) == limit && limit > 0 {
+		next = &list[len(list)-1].ID
+	}
+	return
+}
+
+// FindOne report by its id.
+func FindOne(d deps, id bson.ObjectId) (r Report, err error) {
+	err = d.Mgo().C("vote_reports").FindId(id).One(&r)
+	if err != nil {
+		err = ReportNotFound
+	}
+	return
+}
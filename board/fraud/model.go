@@ -0,0 +1,44 @@
+package fraud
+
+import (
+	"time"
+
+	"github.com/tryanzu/core/core/config"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Kinds of vote manipulation.
+const (
+	KindRing          = "ring"
+	KindSharedAddress = "shared-address"
+	KindSingleTarget  = "single-target"
+)
+
+// Report statuses.
+const (
+	StatusPending     = "pending"
+	StatusDismissed   = "dismissed"
+	StatusNeutralized = "neutralized"
+)
+
+// Report of suspicious votes for moderators to review. Reports are kept
+// per kind and users involved, new votes of the same pattern pile up on
+// the pending report.
+type Report struct {
+	ID         bson.ObjectId   `bson:"_id,omitempty" json:"id"`
+	Kind       string          `bson:"kind" json:"kind"`
+	Key        string          `bson:"key" json:"-"`
+	Users      []bson.ObjectId `bson:"users" json:"users"`
+	Target     *bson.ObjectId  `bson:"target,omitempty" json:"target,omitempty"`
+	Address    string          `bson:"address,omitempty" json:"address,omitempty"`
+	Votes      []bson.ObjectId `bson:"votes" json:"votes"`
+	Status     string          `bson:"status" json:"status"`
+	Reverted   *config.Gains   `bson:"reverted,omitempty" json:"reverted,omitempty"`
+	ResolvedBy *bson.ObjectId  `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	Resolved   *time.Time      `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+	Created    time.Time       `bson:"created_at" json:"created_at"`
+	Updated    time.Time       `bson:"updated_at" json:"updated_at"`
+}
+
+// Reports list.
+type Reports []Report
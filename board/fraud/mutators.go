@@ -0,0 +1,133 @@
+package fraud
+
+import (
+	"time"
+
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/votes"
+	"github.com/tryanzu/core/core/config"
+	"github.com/tryanzu/core/modules/gaming"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// save a detected report into the pending one with the same key. Patterns
+// dismissed by a moderator are not reported again.
+func save(d deps, r Report, now time.Time) (Report, bool, error) {
+	if len(r.Votes) == 0 {
+		return r, false, nil
+	}
+	n, err := d.Mgo().C("vote_reports").Find(bson.M{"key": r.Key, "status": StatusDismissed}).Count()
+	if err != nil || n > 0 {
+		return r, false, err
+	}
+	set := bson.M{"kind": r.Kind, "updated_at": now}
+	if r.Target != nil {
+		set["target"] = *r.Target
+	}
+	if len(r.Address) > 0 {
+		set["address"] = r.Address
+	}
+	_, err = d.Mgo().C("vote_reports").Upsert(bson.M{"key": r.Key, "status": StatusPending}, bson.M{
+		"$set":         set,
+		"$setOnInsert": bson.M{"created_at": now},
+		"$addToSet": bson.M{
+			"users": bson.M{"$each": r.Users},
+			"votes": bson.M{"$each": r.Votes},
+		},
+	})
+	if err != nil {
+		return r, false, err
+	}
+	err = d.Mgo().C("vote_reports").Find(bson.M{"key": r.Key, "status": StatusPending}).One(&r)
+	return r, err == nil, err
+}
+
+// Neutralize the votes of a pending report. They stop counting on the
+// reacted items and what they awarded is taken back.
+func Neutralize(d deps, r Report, by *bson.ObjectId) (Report, error) {
+	var (
+		reverted config.Gains
+		ranked   []bson.ObjectId
+	)
+	for _, id := range r.Votes {
+		v, err := votes.Neutralize(d, id)
+		if err == mgo.ErrNotFound {
+			// Retracted or already neutralized.
+			continue
+		}
+		if err != nil {
+			return r, err
+		}
+		related := "comments"
+		if v.Type == "post" {
+			related = "posts"
+			ranked = append(ranked, v.RelatedID)
+		}
+		err = d.Mgo().C(related).UpdateId(v.RelatedID, bson.M{"$inc": bson.M{v.DbField(): -1}})
+		if err != nil && err != mgo.ErrNotFound {
+			return r, err
+		}
+		err = gaming.Reward(d, v.UserID, v.Awarded.Provider.Neg())
+		if err != nil {
+			return r, err
+		}
+		if v.OwnerID.Valid() {
+			err = gaming.Reward(d, v.OwnerID, v.Awarded.Receiver.Neg())
+			if err != nil {
+				return r, err
+			}
+		}
+		err = votes.InvalidateStats(d, v)
+		if err != nil {
+			return r, err
+		}
+		reverted = reverted.Add(v.Awarded.Provider).Add(v.Awarded.Receiver)
+	}
+	if len(ranked) > 0 {
+		if err := posts.SyncRanks(d, ranked); err != nil {
+			return r, err
+		}
+	}
+	return resolve(d, r, StatusNeutralized, by, bson.M{"reverted": reverted})
+}
+
+// Dismiss a pending report, its pattern won't be reported again.
+func Dismiss(d deps, r Report, by *bson.ObjectId) (Report, error) {
+	return resolve(d, r, StatusDismissed, by, bson.M{})
+}
+
+func resolve(d deps, r Report, status string, by *bson.ObjectId, set bson.M) (Report, error) {
+	now := time.Now()
+	set["status"] = status
+	set["resolved_at"] = now
+	set["updated_at"] = now
+	if by != nil {
+		set["resolved_by"] = *by
+	}
+	err := d.Mgo().C("vote_reports").UpdateId(r.ID, bson.M{"$set": set})
+	if err != nil {
+		return r, err
+	}
+	r.Status = status
+	r.Resolved = &now
+	r.ResolvedBy = by
+	if n, exists := set["reverted"]; exists {
+		reverted := n.(config.Gains)
+		r.Reverted = &reverted
+	}
+	return r, nil
+}
+
+// TrackAddress a user signs in from, accounts sharing one are looked at
+// when detecting vote bursts.
+func TrackAddress(d deps, address string, userID bson.ObjectId) error {
+	if len(address) == 0 {
+		return nil
+	}
+	_, err := d.Mgo().C("trusted_addresses").Upsert(bson.M{"address": address}, bson.M{
+		"$addToSet":    bson.M{"users": userID},
+		"$setOnInsert": bson.M{"banned": false},
+	})
+	return err
+}
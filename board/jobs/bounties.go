@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/tryanzu/core/board/bounties"
+	"github.com/tryanzu/core/core/events"
+	"github.com/tryanzu/core/deps"
+)
+
+func init() {
+	register("refund-expired-bounties", 10*time.Minute, refundExpiredBounties)
+}
+
+// refundExpiredBounties of questions left unsolved.
+func refundExpiredBounties() error {
+	list, err := bounties.FindExpired(deps.Container, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, b := range list {
+		b, err = bounties.Refund(deps.Container, b)
+		if err == bounties.BountyClosed {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		events.In <- events.RawEmit("post."+b.PostID.Hex(), "bounty", map[string]interface{}{
+			"fire":  "bounty-refunded",
+			"coins": b.Coins,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/tryanzu/core/board/fraud"
+	"github.com/tryanzu/core/core/config"
+	"github.com/tryanzu/core/deps"
+)
+
+func init() {
+	register("detect-vote-manipulation", time.Hour, detectManipulation)
+}
+
+// detectManipulation of votes, neutralizing them right away when configured to.
+func detectManipulation() error {
+	reports, err := fraud.Detect(deps.Container, time.Now())
+	if err != nil || len(reports) == 0 {
+		return err
+	}
+	log.Infof("vote manipulation reported	reports=%d", len(reports))
+	if config.C.Copy().Runtime.NeutralizeVotes == false {
+		return nil
+	}
+	for _, r := range reports {
+		_, err = fraud.Neutralize(deps.Container, r, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
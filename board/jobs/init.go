@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/op/go-logging"
+	"github.com/tryanzu/core/core/config"
+)
+
+var (
+	log  = logging.MustGetLogger("jobs")
+	list []job
+)
+
+type job struct {
+	name  string
+	every time.Duration
+	fn    func() error
+}
+
+func (j job) run() {
+	ticker := time.NewTicker(j.every)
+	defer ticker.Stop()
+	for range ticker.C {
+		starts := time.Now()
+		if err := j.fn(); err != nil {
+			log.Errorf("job failed	name=%s err=%v", j.name, err)
+			continue
+		}
+		log.Debugf("job done	name=%s took=%v", j.name, time.Since(starts))
+	}
+}
+
+// register a background job to run on given interval.
+func register(name string, every time.Duration, fn func() error) {
+	list = append(list, job{name, every, fn})
+}
+
+// Boot starts running background jobs. Only the API process should run them.
+func Boot() {
+	log.SetBackend(config.LoggingBackend)
+	for _, j := range list {
+		go j.run()
+	}
+	go func() {
+		for {
+			<-config.C.Reload
+			log.SetBackend(config.LoggingBackend)
+		}
+	}()
+}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"time"
+
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/threads"
+	"github.com/tryanzu/core/core/events"
+	"github.com/tryanzu/core/deps"
+)
+
+func init() {
+	register("publish-scheduled-posts", time.Minute, publishScheduled)
+	register("reopen-closed-posts", time.Minute, reopenClosed)
+	register("enforce-thread-policies", time.Hour, enforcePolicies)
+}
+
+// publishScheduled posts and let everyone know about them just now.
+func publishScheduled() error {
+	published, err := posts.PublishScheduled(deps.Container, time.Now())
+	for _, id := range published {
+		events.In <- events.PostNew(id)
+	}
+	return err
+}
+
+// reopenClosed posts once their closing time is over.
+func reopenClosed() error {
+	reopened, err := threads.ReopenExpired(deps.Container, time.Now())
+	for _, id := range reopened {
+		events.In <- events.RawEmit("feed", "action", map[string]interface{}{
+			"fire": "reopened-post",
+			"id":   id.Hex(),
+		})
+	}
+	return err
+}
+
+// enforcePolicies locking and archiving inactive posts, on behalf of no one.
+func enforcePolicies() error {
+	enforced, err := threads.EnforcePolicies(deps.Container, time.Now())
+	for _, e := range enforced {
+		fire := "closed-post"
+		if e.Action == threads.AutoArchive {
+			fire = "archived-post"
+		}
+		events.In <- events.RawEmit("feed", "action", map[string]interface{}{
+			"fire":     fire,
+			"id":       e.Post.Hex(),
+			"category": e.Category.Hex(),
+			"reason":   e.Reason,
+		})
+		events.In <- events.UpdatePost(events.UserSign{Reason: e.Reason}, e.Post, e.Action)
+	}
+	return err
+}
@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"time"
+
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/ranking"
+	"github.com/tryanzu/core/core/common"
+	"github.com/tryanzu/core/deps"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func init() {
+	register("sync-rising-ranks", 10*time.Minute, syncRising)
+}
+
+// syncRising ranks of young posts, these decay even when nobody interacts with them.
+// Posts aged out of an algorithm window are dropped from its list as well.
+func syncRising() error {
+	now := time.Now()
+	if err := posts.PruneRanks(deps.Container, now); err != nil {
+		return err
+	}
+	since := now.Add(-ranking.Algorithms["rising"].Window())
+	list, err := posts.FindList(deps.Container, common.SoftDelete, func(q bson.M) bson.M {
+		q["created_at"] = bson.M{"$gte": since}
+		return q
+	})
+	if err != nil || len(list) == 0 {
+		return err
+	}
+	return posts.SyncRanks(deps.Container, list.IDs())
+}
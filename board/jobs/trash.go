@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/tryanzu/core/board/trash"
+	"github.com/tryanzu/core/core/config"
+	"github.com/tryanzu/core/deps"
+)
+
+func init() {
+	register("purge-trash", time.Hour, purgeTrash)
+}
+
+// purgeTrash of items past their retention period.
+func purgeTrash() error {
+	days := config.C.Copy().Runtime.TrashRetentionDays()
+	purged, err := trash.Purge(deps.Container, time.Now().AddDate(0, 0, -days))
+	if purged > 0 {
+		log.Infof("trash purged	items=%d", purged)
+	}
+	return err
+}
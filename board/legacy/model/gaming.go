@@ -17,4 +17,7 @@ type RuleModel struct {
 	Tribute int    `json:"tribute"`
 	Shit    int    `json:"shit"`
 	Coins   int    `json:"coins"`
+
+	// Reactions a user of the level can give per day, zero means no quota.
+	Reactions int `json:"reactions"`
 }
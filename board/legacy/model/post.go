@@ -93,6 +93,7 @@ type Post struct {
 	Created           time.Time       `bson:"created_at" json:"created_at"`
 	Updated           time.Time       `bson:"updated_at" json:"updated_at"`
 	Deleted           time.Time       `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	PublishAt         *time.Time      `bson:"publish_at,omitempty" json:"publish_at,omitempty"`
 }
 
 type PostCommentModel struct {
@@ -158,6 +159,9 @@ type PostForm struct {
 	IsQuestion bool                   `json:"is_question"`
 	Pinned     bool                   `json:"pinned"`
 	Lock       bool                   `json:"lock"`
+	Reason     string                 `json:"reason"`
+	PublishAt  *time.Time             `json:"publish_at"`
+	DraftID    string                 `json:"draft_id"`
 	Components map[string]interface{} `json:"components"`
 }
 
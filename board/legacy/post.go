@@ -18,7 +18,10 @@ import (
 	"github.com/olebedev/config"
 	"github.com/tryanzu/core/board/legacy/model"
 	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/ranking"
+	"github.com/tryanzu/core/board/relations"
 	"github.com/tryanzu/core/core/events"
+	"github.com/tryanzu/core/core/user"
 	"github.com/tryanzu/core/deps"
 	"github.com/tryanzu/core/modules/acl"
 	"github.com/tryanzu/core/modules/exceptions"
@@ -39,6 +42,61 @@ type PostAPI struct {
 }
 
 func (di PostAPI) FeedGet(c *gin.Context) {
+	di.feed(c, bson.M{})
+}
+
+// FeedHome merges the signed user subscribed categories, followed users and
+// followed posts, leaving muted and blocked content out.
+func (di PostAPI) FeedHome(c *gin.Context) {
+	var (
+		usr   = c.MustGet("user").(user.User)
+		and   = []bson.M{}
+		or    = []bson.M{}
+		lists = map[string][]bson.ObjectId{}
+	)
+	for _, r := range [][2]string{
+		{relations.Follow, "user"},
+		{relations.Follow, "post"},
+		{relations.Mute, "user"},
+		{relations.Mute, "category"},
+		{relations.Block, "user"},
+	} {
+		list, err := relations.FindRelated(deps.Container, usr.Id, r[0], r[1])
+		if err != nil {
+			c.JSON(500, gin.H{"status": "error", "message": err.Error()})
+			return
+		}
+		lists[r[0]+":"+r[1]] = list
+	}
+	if len(usr.Categories) > 0 {
+		or = append(or, bson.M{"category": bson.M{"$in": usr.Categories}})
+	}
+	if followed := lists["follow:user"]; len(followed) > 0 {
+		or = append(or, bson.M{"user_id": bson.M{"$in": followed}})
+	}
+	if followed := lists["follow:post"]; len(followed) > 0 {
+		or = append(or, bson.M{"_id": bson.M{"$in": followed}})
+	}
+
+	// New users not following anything yet get the global feed.
+	if len(or) > 0 {
+		and = append(and, bson.M{"$or": or})
+	}
+	if muted := lists["mute:category"]; len(muted) > 0 {
+		and = append(and, bson.M{"category": bson.M{"$nin": muted}})
+	}
+	if excluded := append(lists["mute:user"], lists["block:user"]...); len(excluded) > 0 {
+		and = append(and, bson.M{"user_id": bson.M{"$nin": excluded}})
+	}
+	base := bson.M{}
+	if len(and) > 0 {
+		base["$and"] = and
+	}
+	di.feed(c, base)
+}
+
+// feed of posts matching given base criteria and the request filters.
+func (di PostAPI) feed(c *gin.Context, base bson.M) {
 	var (
 		feed     []model.FeedPost
 		search   = bson.M{}
@@ -47,11 +105,15 @@ func (di PostAPI) FeedGet(c *gin.Context) {
 		database = deps.Container.Mgo()
 	)
 
+	for k, v := range base {
+		search[k] = v
+	}
+
 	if n, err := strconv.Atoi(c.Query("offset")); err == nil && n > 0 {
 		offset = n
 	}
 
-	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n < 40 {
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 && n < 40 {
 		limit = n
 	}
 
@@ -104,18 +166,66 @@ func (di PostAPI) FeedGet(c *gin.Context) {
 		}
 	}
 
+	var cursor *posts.Cursor
+	if s := c.Query("cursor"); len(s) > 0 {
+		decoded, err := posts.DecodeCursor(s)
+		if err != nil {
+			c.JSON(400, gin.H{"status": "error", "message": err.Error()})
+			return
+		}
+		cursor = &decoded
+	}
+
+	// One extra item tells whether there is a page past this one.
+	more := false
+	scores := map[bson.ObjectId]int64{}
+
+	// Ranked feeds are read from a sorted set of scores, relevant rates are kept per day.
+	ranked := ""
+	window := time.Hour * 24 * 30
 	if relevant != "" {
-		list, err := posts.FindRateList(deps.Container, relevant, offset*4, limit*4)
+		ranked = posts.RateList(relevant)
+	}
+	if sort := c.Query("sort"); len(sort) > 0 && sort != "recent" {
+		algo, exists := ranking.Get(sort)
+		if exists == false {
+			c.JSON(400, gin.H{"status": "error", "message": "Unknown feed sort"})
+			return
+		}
+		ranked, window = posts.RankList(sort), algo.Window()
+	}
+
+	if ranked != "" {
+		// Ranked posts may be filtered out, so read ahead within the same bounds.
+		fetch := (offset + limit) * 4
+		rated, err := posts.FindRatedAfter(deps.Container, ranked, cursor, fetch)
 		if err != nil {
 			log.Printf("[err] %v\n", err)
 		}
-		if err == nil && len(list) > 0 {
+		more = len(rated) == fetch
+		if cursor == nil && len(rated) > offset*4 {
+			rated = rated[offset*4:]
+		} else if cursor == nil {
+			rated = rated[:0]
+		}
+		if err == nil && len(rated) > 0 {
 			var temp []model.FeedPost
-			err := database.C("posts").Find(bson.M{
-				"_id":        bson.M{"$in": list},
-				"deleted_at": bson.M{"$exists": false},
-				"created_at": bson.M{"$gte": time.Now().Add(time.Hour * 24 * 30 * -1)},
-			}).Select(bson.M{"comments.set": 0, "content": 0, "components": 0}).All(&temp)
+			list := make([]bson.ObjectId, len(rated))
+			for n, r := range rated {
+				list[n] = r.ID
+				scores[r.ID] = r.Score
+			}
+			criteria := bson.M{
+				"_id":         bson.M{"$in": list},
+				"deleted_at":  bson.M{"$exists": false},
+				"publish_at":  bson.M{"$exists": false},
+				"archived_at": bson.M{"$exists": false},
+				"created_at":  bson.M{"$gte": time.Now().Add(-window)},
+			}
+			for k, v := range base {
+				criteria[k] = v
+			}
+			err := database.C("posts").Find(criteria).Select(bson.M{"comments.set": 0, "content": 0, "components": 0}).All(&temp)
 
 			if err != nil {
 				panic(err)
@@ -123,7 +233,7 @@ func (di PostAPI) FeedGet(c *gin.Context) {
 
 			feed = []model.FeedPost{}
 
-			// Using the temp feed we will have to manually order them by the natural order given by the relevant list
+			// Using the temp feed we will have to manually order them by the natural order given by the ranked list
 			for _, id := range list {
 				for _, post := range temp {
 					if post.Id == id {
@@ -142,8 +252,16 @@ func (di PostAPI) FeedGet(c *gin.Context) {
 
 	} else {
 
-		// Get all but deleted
+		// Get all but deleted, scheduled & archived
 		search["deleted_at"] = bson.M{"$exists": false}
+		search["publish_at"] = bson.M{"$exists": false}
+		search["archived_at"] = bson.M{"$exists": false}
+
+		if cursor != nil {
+			for k, v := range cursor.Query(!user_order) {
+				search[k] = v
+			}
+		}
 
 		// Prepare the database to fetch the feed
 		query := database.C("posts").Find(search).Select(bson.M{"comments.set": 0, "content": 0, "components": 0})
@@ -151,13 +269,20 @@ func (di PostAPI) FeedGet(c *gin.Context) {
 		// Add the sort depending on the context
 		if user_order {
 			count, _ = query.Count()
-			query = query.Sort("-created_at")
+		}
+		if cursor != nil {
+			query = query.Sort(cursor.Sort(!user_order)...)
+		} else if user_order {
+			query = query.Sort("-created_at", "-_id")
 		} else {
-			query = query.Sort("-pinned", "-created_at")
+			query = query.Sort("-pinned", "-created_at", "-_id")
 		}
 
 		// Add the limits of the resultset
-		query = query.Limit(limit).Skip(offset)
+		query = query.Limit(limit + 1)
+		if cursor == nil {
+			query = query.Skip(offset)
+		}
 
 		// Get the results from the feed algo
 		err := query.All(&feed)
@@ -166,6 +291,37 @@ func (di PostAPI) FeedGet(c *gin.Context) {
 		}
 	}
 
+	if len(feed) > limit {
+		feed, more = feed[:limit], true
+	}
+
+	// Back cursors walk the feed upwards, so restore the natural order.
+	if cursor != nil && cursor.Back {
+		for i, j := 0, len(feed)-1; i < j; i, j = i+1, j-1 {
+			feed[i], feed[j] = feed[j], feed[i]
+		}
+	}
+
+	pages := gin.H{}
+	if len(feed) > 0 {
+		at := func(post model.FeedPost, back bool) string {
+			return posts.Cursor{
+				Pinned:  post.Pinned && !user_order && ranked == "",
+				Created: post.Created,
+				Score:   scores[post.Id],
+				ID:      post.Id,
+				Back:    back,
+			}.Encode()
+		}
+		back := cursor != nil && cursor.Back
+		if more || back {
+			pages["next"] = at(feed[len(feed)-1], false)
+		}
+		if (more && back) || (!back && (cursor != nil || offset > 0)) {
+			pages["prev"] = at(feed[0], true)
+		}
+	}
+
 	var authors []bson.ObjectId
 	var list []bson.ObjectId
 	var users []model.User
@@ -220,11 +376,14 @@ func (di PostAPI) FeedGet(c *gin.Context) {
 			}
 		}
 
+		res := gin.H{"feed": feed, "offset": offset, "limit": limit}
 		if count > 0 {
-			c.JSON(200, gin.H{"feed": feed, "offset": offset, "limit": limit, "count": count})
-		} else {
-			c.JSON(200, gin.H{"feed": feed, "offset": offset, "limit": limit})
+			res["count"] = count
+		}
+		for k, v := range pages {
+			res[k] = v
 		}
+		c.JSON(200, res)
 
 	} else {
 		c.JSON(200, gin.H{"feed": []string{}, "offset": offset, "limit": limit})
@@ -326,8 +485,8 @@ func (di PostAPI) PostDelete(c *gin.Context) {
 	}
 
 	err = deps.Container.Mgo().C("posts").Update(bson.M{"_id": post.Id}, bson.M{
-		"$set":   bson.M{"deleted": true, "deleted_at": time.Now()},
-		"$unset": bson.M{"pinned": ""},
+		"$set":    bson.M{"deleted": true, "deleted_at": time.Now(), "deleted_by": uid},
+		"$rename": bson.M{"pinned": "deleted_pinned"},
 	})
 	if err != nil {
 		panic(err)
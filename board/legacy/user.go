@@ -3,6 +3,7 @@ package handle
 import (
 	"bytes"
 	"fmt"
+	"log"
 	"strings"
 
 	jwt "github.com/dgrijalva/jwt-go"
@@ -12,7 +13,9 @@ import (
 	"github.com/mitchellh/goamz/s3"
 	"github.com/nfnt/resize"
 	"github.com/tryanzu/core/board/comments"
+	"github.com/tryanzu/core/board/fraud"
 	"github.com/tryanzu/core/board/legacy/model"
+	"github.com/tryanzu/core/board/votes"
 	"github.com/tryanzu/core/core/config"
 	"github.com/tryanzu/core/core/events"
 	u "github.com/tryanzu/core/core/user"
@@ -183,10 +186,14 @@ func (di *UserAPI) UserGetByToken(c *gin.Context) {
 		return
 	}
 
+	address := c.ClientIP()
 	go func(usr *user.One) {
 
 		// Track user sign in
-		usr.TrackUserSignin(c.ClientIP())
+		usr.TrackUserSignin(address)
+		if err := fraud.TrackAddress(deps.Container, address, usr.Data().Id); err != nil {
+			log.Printf("[err] %v\n", err)
+		}
 
 		// Does daily login calculations
 		g := di.Gaming.Get(usr)
@@ -202,8 +209,18 @@ func (di *UserAPI) UserGetByToken(c *gin.Context) {
 		data.Categories = make([]bson.ObjectId, 0)
 	}
 
+	// Reactions left today under the user level quota.
+	quota, err := votes.FindQuota(deps.Container, uid, gaming.ReactionQuota(deps.Container, data.Gaming.Level), time.Now())
+	if err != nil {
+		c.JSON(500, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
 	// Alright, go back and send the user info
-	c.JSON(200, data)
+	c.JSON(200, struct {
+		*user.UserPrivate
+		Quota votes.Quota `json:"reactions_quota"`
+	}{data, quota})
 }
 
 func (di UserAPI) UserGetJwtToken(c *gin.Context) {
@@ -228,6 +245,9 @@ func (di UserAPI) UserGetJwtToken(c *gin.Context) {
 			return
 		}
 	}
+	if err := fraud.TrackAddress(deps.Container, c.ClientIP(), usr.Data().Id); err != nil {
+		log.Printf("[err] %v\n", err)
+	}
 	sessionID := c.MustGet("session_id").(string)
 	remember := 72
 	if n, err := strconv.Atoi(qs.Get("remember")); err == nil {
@@ -331,6 +351,11 @@ func (di *UserAPI) UserUpdateProfile(c *gin.Context) {
 			return
 		}
 
+		if di.User.AllowedUsername(uid, username) == false {
+			c.JSON(400, gin.H{"status": "error", "message": "Username not allowed by site rules."})
+			return
+		}
+
 		usernameSlug := sanitize.Path(sanitize.Accents(username))
 
 		// Check whether user exists
@@ -6,6 +6,11 @@ import (
 	"time"
 )
 
+// Aggregated notification types pile up in a single unseen notification per related item.
+var Aggregated = map[string]bool{
+	"thread": true,
+}
+
 func databaseWorker(n int) {
 	for n := range Database {
 		n.Id = bson.NewObjectId()
@@ -13,12 +18,33 @@ func databaseWorker(n int) {
 		n.Created = time.Now()
 		n.Updated = time.Now()
 
-		err := deps.Container.Mgo().C("notifications").Insert(n)
-		if err != nil {
-			panic(err)
+		if Aggregated[n.Type] {
+			changes, err := deps.Container.Mgo().C("notifications").Upsert(bson.M{
+				"user_id":    n.UserId,
+				"type":       n.Type,
+				"related_id": n.RelatedId,
+				"seen":       false,
+			}, bson.M{
+				"$addToSet":    bson.M{"users": bson.M{"$each": n.Users}},
+				"$set":         bson.M{"updated_at": n.Updated},
+				"$setOnInsert": bson.M{"created_at": n.Created},
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			// Unseen notifications are already counted.
+			if changes.UpsertedId == nil {
+				continue
+			}
+		} else {
+			err := deps.Container.Mgo().C("notifications").Insert(n)
+			if err != nil {
+				panic(err)
+			}
 		}
 
-		err = deps.Container.Mgo().C("users").Update(bson.M{"_id": n.UserId}, bson.M{"$inc": bson.M{"notifications": 1}})
+		err := deps.Container.Mgo().C("users").Update(bson.M{"_id": n.UserId}, bson.M{"$inc": bson.M{"notifications": 1}})
 		if err != nil {
 			panic(err)
 		}
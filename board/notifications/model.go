@@ -1,9 +1,11 @@
 package notifications
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/tryanzu/core/board/comments"
+	"github.com/tryanzu/core/board/flags"
 	posts "github.com/tryanzu/core/board/posts"
 	"github.com/tryanzu/core/core/common"
 	"github.com/tryanzu/core/core/user"
@@ -62,6 +64,40 @@ func (all Notifications) CommentsScope() common.Scope {
 	return common.WithinID(list)
 }
 
+// ThreadIDs of the posts related to thread notifications.
+func (all Notifications) ThreadIDs() []bson.ObjectId {
+	list := []bson.ObjectId{}
+	for _, n := range all {
+		if n.Type == "thread" {
+			list = append(list, n.RelatedId)
+		}
+	}
+	return list
+}
+
+// FlagIDs of the flags related to flag notifications.
+func (all Notifications) FlagIDs() []bson.ObjectId {
+	list := []bson.ObjectId{}
+	for _, n := range all {
+		if n.Type == "flag" {
+			list = append(list, n.RelatedId)
+		}
+	}
+	return list
+}
+
+// flagTitles by the status reported flags were resolved with.
+var flagTitles = map[string]string{
+	"accepted":  "Revisamos tu reporte y tomamos acciones, gracias",
+	"rejected":  "Revisamos tu reporte y no encontramos una falta",
+	"escalated": "Tu reporte pasó a una revisión adicional",
+}
+
+// commentContext API path to fetch a comment within its thread.
+func commentContext(postID, id bson.ObjectId) string {
+	return "/v1/comments/" + postID.Hex() + "/context/" + id.Hex()
+}
+
 func (all Notifications) Humanize(deps Deps) (list []map[string]interface{}, err error) {
 	ulist, err := user.FindList(deps, all.UsersScope())
 	if err != nil {
@@ -75,7 +111,12 @@ func (all Notifications) Humanize(deps Deps) (list []map[string]interface{}, err
 		return
 	}
 
-	plist, err := posts.FindList(deps, clist.PostsScope())
+	flist, err := flags.FindList(deps, common.WithinID(all.FlagIDs()))
+	if err != nil {
+		panic(err)
+	}
+
+	plist, err := posts.FindList(deps, common.WithinID(append(append(clist.PostIDs(), all.ThreadIDs()...), flist.PostIDs()...)))
 	if err != nil {
 		panic(err)
 		return
@@ -84,6 +125,7 @@ func (all Notifications) Humanize(deps Deps) (list []map[string]interface{}, err
 	umap := ulist.Map()
 	cmap := clist.Map()
 	pmap := plist.Map()
+	fmap := flist.Map()
 
 	for _, n := range all {
 		switch n.Type {
@@ -95,22 +137,57 @@ func (all Notifications) Humanize(deps Deps) (list []map[string]interface{}, err
 			list = append(list, map[string]interface{}{
 				"id":        n.Id.Hex(),
 				"target":    "/p/" + post.Slug + "/" + post.Id.Hex() + "#" + n.RelatedId.Hex(),
+				"context":   commentContext(post.Id, n.RelatedId),
 				"title":     "Nuevo comentario de @" + user.UserName,
 				"subtitle":  post.Title,
 				"createdAt": n.Created,
 			})
 		case "mention":
 			comment := cmap[n.RelatedId]
-			post := pmap[comment.ReplyTo]
+			post := pmap[comment.RelatedPost()]
 			user := umap[comment.UserId]
 
 			list = append(list, map[string]interface{}{
 				"id":        n.Id.Hex(),
-				"target":    "/p/" + post.Slug + "/" + post.Id.Hex(), /*+ "#c" + comment.Id.Hex()*/
+				"target":    "/p/" + post.Slug + "/" + post.Id.Hex() + "#" + comment.Id.Hex(),
+				"context":   commentContext(post.Id, comment.Id),
 				"title":     "@" + user.UserName + " te mencionó en un comentario",
 				"subtitle":  post.Title,
 				"createdAt": n.Created,
 			})
+		case "thread":
+			post := pmap[n.RelatedId]
+			title := "Nuevo comentario en un tema que sigues"
+			if len(n.Users) > 0 {
+				user := umap[n.Users[len(n.Users)-1]]
+				title = "@" + user.UserName + " comentó en un tema que sigues"
+				if len(n.Users) > 1 {
+					title = "@" + user.UserName + " y " + strconv.Itoa(len(n.Users)-1) + " más comentaron en un tema que sigues"
+				}
+			}
+			list = append(list, map[string]interface{}{
+				"id":        n.Id.Hex(),
+				"target":    "/p/" + post.Slug + "/" + post.Id.Hex(),
+				"title":     title,
+				"subtitle":  post.Title,
+				"createdAt": n.Updated,
+			})
+		case "flag":
+			flag := fmap[n.RelatedId]
+			target := "/"
+			subtitle := ""
+			if flag.PostID != nil {
+				post := pmap[*flag.PostID]
+				target = "/p/" + post.Slug + "/" + post.Id.Hex()
+				subtitle = post.Title
+			}
+			list = append(list, map[string]interface{}{
+				"id":        n.Id.Hex(),
+				"target":    target,
+				"title":     flagTitles[string(flag.Status)],
+				"subtitle":  subtitle,
+				"createdAt": n.Created,
+			})
 		case "chat":
 			user := umap[n.Users[0]]
 			list = append(list, map[string]interface{}{
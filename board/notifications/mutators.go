@@ -0,0 +1,11 @@
+package notifications
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MarkSeen all user notifications, so aggregated ones start over.
+func MarkSeen(deps Deps, userID bson.ObjectId) error {
+	_, err := deps.Mgo().C("notifications").UpdateAll(bson.M{"user_id": userID, "seen": false}, bson.M{"$set": bson.M{"seen": true}})
+	return err
+}
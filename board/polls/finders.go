@@ -0,0 +1,37 @@
+package polls
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tryanzu/core/board/votes"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var PollNotFound = errors.New("Poll has not been found by given criteria.")
+
+func FindId(d deps, id bson.ObjectId) (p Poll, err error) {
+	err = d.Mgo().C("polls").FindId(id).One(&p)
+	if err != nil {
+		err = PollNotFound
+	}
+	return
+}
+
+// FindByPost gets the poll attached to a post.
+func FindByPost(d deps, postID bson.ObjectId) (p Poll, err error) {
+	err = d.Mgo().C("polls").Find(bson.M{"post_id": postID}).One(&p)
+	if err != nil {
+		err = PollNotFound
+	}
+	return
+}
+
+// FindResults of a poll as seen by a viewer.
+func FindResults(d deps, p Poll, viewer bson.ObjectId) (Results, error) {
+	list, err := votes.FindActiveList(d, p)
+	if err != nil {
+		return Results{}, err
+	}
+	return p.Tally(list, viewer, time.Now()), nil
+}
@@ -0,0 +1,98 @@
+package polls
+
+import (
+	"time"
+
+	"github.com/tryanzu/core/board/votes"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Option a poll can be voted for.
+type Option struct {
+	ID   string `bson:"id" json:"id"`
+	Text string `bson:"text" json:"text"`
+}
+
+// Poll attached to a post.
+type Poll struct {
+	ID          bson.ObjectId `bson:"_id,omitempty" json:"id"`
+	PostID      bson.ObjectId `bson:"post_id" json:"post_id"`
+	UserID      bson.ObjectId `bson:"user_id" json:"user_id"`
+	Question    string        `bson:"question" json:"question"`
+	Options     []Option      `bson:"options" json:"options"`
+	Multiple    bool          `bson:"multiple" json:"multiple"`
+	Anonymous   bool          `bson:"anonymous" json:"anonymous"`
+	HideResults bool          `bson:"hide_results" json:"hide_results"`
+	ClosesAt    *time.Time    `bson:"closes_at,omitempty" json:"closes_at,omitempty"`
+	Closed      *time.Time    `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+	Created     time.Time     `bson:"created_at" json:"created_at"`
+}
+
+func (Poll) VotableType() string {
+	return "poll"
+}
+
+func (p Poll) VotableID() bson.ObjectId {
+	return p.ID
+}
+
+// IsClosed either early by its author or by its close date.
+func (p Poll) IsClosed(now time.Time) bool {
+	return p.Closed != nil || (p.ClosesAt != nil && now.After(*p.ClosesAt))
+}
+
+// HasOption with given id.
+func (p Poll) HasOption(id string) bool {
+	for _, o := range p.Options {
+		if o.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Results of a poll as seen by a viewer.
+type Results struct {
+	Total  int                        `json:"total"`
+	Counts map[string]int             `json:"counts,omitempty"`
+	Voters map[string][]bson.ObjectId `json:"voters,omitempty"`
+	Mine   []string                   `json:"mine,omitempty"`
+	Hidden bool                       `json:"hidden"`
+	Closed bool                       `json:"closed"`
+}
+
+// Tally votes for a viewer. Hidden results are only shown after
+// voting or once the poll closes, voters are never shown for anonymous polls.
+func (p Poll) Tally(list votes.List, viewer bson.ObjectId, now time.Time) Results {
+	r := Results{
+		Counts: make(map[string]int, len(p.Options)),
+		Closed: p.IsClosed(now),
+	}
+	if p.Anonymous == false {
+		r.Voters = make(map[string][]bson.ObjectId, len(p.Options))
+	}
+	for _, o := range p.Options {
+		r.Counts[o.ID] = 0
+	}
+	voters := map[bson.ObjectId]struct{}{}
+	for _, v := range list {
+		if p.HasOption(v.Value) == false {
+			continue
+		}
+		voters[v.UserID] = struct{}{}
+		r.Counts[v.Value]++
+		if r.Voters != nil {
+			r.Voters[v.Value] = append(r.Voters[v.Value], v.UserID)
+		}
+		if viewer.Valid() && v.UserID == viewer {
+			r.Mine = append(r.Mine, v.Value)
+		}
+	}
+	r.Total = len(voters)
+	if p.HideResults && r.Closed == false && len(r.Mine) == 0 {
+		r.Hidden = true
+		r.Counts = nil
+		r.Voters = nil
+	}
+	return r
+}
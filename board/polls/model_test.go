@@ -0,0 +1,56 @@
+package polls
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/tryanzu/core/board/votes"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestTally(t *testing.T) {
+	alice, bob := bson.NewObjectId(), bson.NewObjectId()
+	now := time.Now()
+	poll := Poll{
+		ID:      bson.NewObjectId(),
+		Options: []Option{{"0", "Yes"}, {"1", "No"}},
+	}
+	list := votes.List{
+		{UserID: alice, Value: "0"},
+		{UserID: bob, Value: "0"},
+		{UserID: bob, Value: "1"},
+		{UserID: bob, Value: "9"},
+	}
+
+	Convey("Poll results are tallied for each viewer", t, func() {
+		Convey("Counts options and distinct voters", func() {
+			r := poll.Tally(list, alice, now)
+			So(r.Total, ShouldEqual, 2)
+			So(r.Counts["0"], ShouldEqual, 2)
+			So(r.Counts["1"], ShouldEqual, 1)
+			So(r.Mine, ShouldResemble, []string{"0"})
+			So(len(r.Voters["0"]), ShouldEqual, 2)
+		})
+
+		Convey("Anonymous polls never show voters", func() {
+			p := poll
+			p.Anonymous = true
+			So(p.Tally(list, alice, now).Voters, ShouldBeNil)
+		})
+
+		Convey("Hidden results show up only after voting or closing", func() {
+			p := poll
+			p.HideResults = true
+			r := p.Tally(list, bson.NewObjectId(), now)
+			So(r.Hidden, ShouldBeTrue)
+			So(r.Counts, ShouldBeNil)
+			So(r.Total, ShouldEqual, 2)
+			So(p.Tally(list, alice, now).Hidden, ShouldBeFalse)
+
+			closes := now.Add(-time.Minute)
+			p.ClosesAt = &closes
+			So(p.Tally(list, bson.NewObjectId(), now).Hidden, ShouldBeFalse)
+		})
+	})
+}
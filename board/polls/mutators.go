@@ -0,0 +1,70 @@
+package polls
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tryanzu/core/board/votes"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	InvalidPoll = errors.New("Polls need a question and between 2 and 10 options.")
+	PollExists  = errors.New("Post already has a poll.")
+	PollClosed  = errors.New("Poll is already closed.")
+)
+
+// CreatePoll for a post. Option ids are assigned by position.
+func CreatePoll(d deps, p Poll, options []string) (Poll, error) {
+	p.Question = strings.TrimSpace(p.Question)
+	p.Options = []Option{}
+	for _, text := range options {
+		if text = strings.TrimSpace(text); len(text) > 0 {
+			p.Options = append(p.Options, Option{ID: strconv.Itoa(len(p.Options)), Text: text})
+		}
+	}
+	if len(p.Question) == 0 || len(p.Options) < 2 || len(p.Options) > 10 {
+		return p, InvalidPoll
+	}
+	if p.ClosesAt != nil && p.ClosesAt.Before(time.Now()) {
+		return p, InvalidPoll
+	}
+	if _, err := FindByPost(d, p.PostID); err == nil {
+		return p, PollExists
+	}
+	p.ID = bson.NewObjectId()
+	p.Created = time.Now()
+	err := d.Mgo().C("polls").Insert(&p)
+	return p, err
+}
+
+// Vote toggles the user vote for an option. Single choice polls keep only the latest one.
+func Vote(d deps, p Poll, userID bson.ObjectId, option string) error {
+	if p.IsClosed(time.Now()) {
+		return PollClosed
+	}
+	if p.HasOption(option) == false {
+		return &votes.NotAllowed{Reason: "invalid option"}
+	}
+	_, status, err := votes.ToggleVote(d, p, userID, option)
+	if err != nil {
+		return err
+	}
+	if p.Multiple == false && status.Active {
+		err = votes.RetractOthers(d, p, userID, option)
+	}
+	return err
+}
+
+// Close a poll before its close date.
+func Close(d deps, p Poll) (Poll, error) {
+	if p.IsClosed(time.Now()) {
+		return p, PollClosed
+	}
+	closed := time.Now()
+	p.Closed = &closed
+	err := d.Mgo().C("polls").UpdateId(p.ID, bson.M{"$set": bson.M{"closed_at": closed}})
+	return p, err
+}
@@ -0,0 +1,91 @@
+package post
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// InvalidCursor err.
+var InvalidCursor = errors.New("invalid cursor")
+
+// Cursor points to a feed item by its sort keys. Back cursors page towards newer items.
+type Cursor struct {
+	Pinned  bool          `json:"p,omitempty"`
+	Created time.Time     `json:"t,omitempty"`
+	Score   int64         `json:"s,omitempty"`
+	ID      bson.ObjectId `json:"i"`
+	Back    bool          `json:"b,omitempty"`
+}
+
+// Encode cursor as an opaque string.
+func (c Cursor) Encode() string {
+	bytes, err := json.Marshal(c)
+	if err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// DecodeCursor from its opaque form.
+func DecodeCursor(s string) (c Cursor, err error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, InvalidCursor
+	}
+	err = json.Unmarshal(bytes, &c)
+	if err != nil || c.ID.Valid() == false {
+		return c, InvalidCursor
+	}
+	return
+}
+
+// Query items past the cursor in (-pinned, -created_at, -_id) order,
+// pinned is left out of the keys when ordering by date only.
+func (c Cursor) Query(pinned bool) bson.M {
+	cmp := "$lt"
+	if c.Back {
+		cmp = "$gt"
+	}
+	or := []bson.M{}
+	same := bson.M{}
+	if pinned {
+		same["pinned"] = true
+		if c.Pinned == false {
+			same["pinned"] = bson.M{"$ne": true}
+		}
+		if c.Pinned != c.Back {
+			// Pinned items come first so moving past them means moving into the other group.
+			other := bson.M{"pinned": bson.M{"$ne": true}}
+			if c.Back {
+				other = bson.M{"pinned": true}
+			}
+			or = append(or, other)
+		}
+	}
+	byDate := bson.M{"created_at": bson.M{cmp: c.Created}}
+	byID := bson.M{"created_at": c.Created, "_id": bson.M{cmp: c.ID}}
+	for k, v := range same {
+		byDate[k] = v
+		byID[k] = v
+	}
+	or = append(or, byDate, byID)
+	return bson.M{"$or": or}
+}
+
+// Sort fields matching the cursor direction.
+func (c Cursor) Sort(pinned bool) []string {
+	fields := []string{"-created_at", "-_id"}
+	if pinned {
+		fields = append([]string{"-pinned"}, fields...)
+	}
+	if c.Back {
+		for n, f := range fields {
+			fields[n] = f[1:]
+		}
+	}
+	return fields
+}
@@ -0,0 +1,51 @@
+package post
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestCursor(t *testing.T) {
+	at := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	id := bson.NewObjectId()
+
+	Convey("Feed cursors", t, func() {
+		Convey("Survive encoding", func() {
+			c := Cursor{Pinned: true, Created: at, ID: id, Back: true}
+			decoded, err := DecodeCursor(c.Encode())
+			So(err, ShouldBeNil)
+			So(decoded.ID, ShouldEqual, id)
+			So(decoded.Created.Equal(at), ShouldBeTrue)
+			So(decoded.Pinned, ShouldBeTrue)
+			So(decoded.Back, ShouldBeTrue)
+		})
+
+		Convey("Reject garbage", func() {
+			_, err := DecodeCursor("not a cursor")
+			So(err, ShouldEqual, InvalidCursor)
+		})
+
+		Convey("Moving forward from pinned items reaches the rest", func() {
+			q := Cursor{Pinned: true, Created: at, ID: id}.Query(true)
+			or := q["$or"].([]bson.M)
+			So(len(or), ShouldEqual, 3)
+			So(or[0], ShouldResemble, bson.M{"pinned": bson.M{"$ne": true}})
+			So(or[1], ShouldResemble, bson.M{"pinned": true, "created_at": bson.M{"$lt": at}})
+		})
+
+		Convey("Moving forward from regular items stays within them", func() {
+			q := Cursor{Created: at, ID: id}.Query(true)
+			So(len(q["$or"].([]bson.M)), ShouldEqual, 2)
+		})
+
+		Convey("Date only ordering ignores pinned", func() {
+			c := Cursor{Created: at, ID: id, Back: true}
+			or := c.Query(false)["$or"].([]bson.M)
+			So(or[1], ShouldResemble, bson.M{"created_at": at, "_id": bson.M{"$gt": id}})
+			So(c.Sort(false), ShouldResemble, []string{"created_at", "_id"})
+		})
+	})
+}
@@ -2,17 +2,30 @@ package post
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"time"
 
 	"github.com/tryanzu/core/core/common"
+	"github.com/tryanzu/core/modules/helpers"
 	"gopkg.in/mgo.v2/bson"
 )
 
 // PostNotFound err.
 var PostNotFound = errors.New("post has not been found by given criteria")
 
+// ScheduledNotFound err.
+var ScheduledNotFound = errors.New("scheduled post has not been found, it may be published already")
+
+// RevisionNotFound err.
+var RevisionNotFound = errors.New("revision has not been found by given criteria")
+
+// FindId post, scheduled posts are left out until published.
 func FindId(deps deps, id bson.ObjectId) (post Post, err error) {
-	err = deps.Mgo().C("posts").FindId(id).One(&post)
+	err = deps.Mgo().C("posts").Find(bson.M{
+		"_id":        id,
+		"publish_at": bson.M{"$exists": false},
+	}).One(&post)
 	return
 }
 
@@ -34,3 +47,172 @@ func FindRateList(d deps, date string, offset, limit int) ([]bson.ObjectId, erro
 	log.Info("getting rate list at %s", date)
 	return list, err
 }
+
+// Rated post id in a rate list.
+type Rated struct {
+	ID    bson.ObjectId
+	Score int64
+}
+
+// FindLatest published posts within given list, last active first.
+func FindLatest(d deps, list []bson.ObjectId, offset, limit int) (posts Posts, err error) {
+	posts = Posts{}
+	err = d.Mgo().C("posts").Find(bson.M{
+		"_id":        bson.M{"$in": list},
+		"deleted_at": bson.M{"$exists": false},
+		"publish_at": bson.M{"$exists": false},
+	}).Select(bson.M{"content": 0}).Sort("-updated_at").Skip(offset).Limit(limit).All(&posts)
+	return
+}
+
+// RateList key of the daily relevant rates.
+func RateList(date string) string {
+	return "posts:" + date
+}
+
+// RankList key of a ranking algorithm scores.
+func RankList(name string) string {
+	return "rank:" + name
+}
+
+// Uniques counts of distinct users over time windows.
+type Uniques struct {
+	Total int `json:"total"`
+	Day   int `json:"day"`
+	Week  int `json:"week"`
+}
+
+const (
+	dayTTL  = int64(2 * 24 * 60 * 60)
+	weekTTL = int64(8 * 24 * 60 * 60)
+)
+
+type sketchKey struct {
+	key string
+	ttl int64
+}
+
+// uniqueKeys of the post sketches of given kind, "views" or "reached".
+func uniqueKeys(kind string, id bson.ObjectId, at time.Time) []sketchKey {
+	base := "posts:" + kind + ":hll:" + id.Hex()
+	year, week := at.ISOWeek()
+	return []sketchKey{
+		{base, 0},
+		{base + ":day:" + at.Format("2006-01-02"), dayTTL},
+		{fmt.Sprintf("%s:week:%d/%d", base, year, week), weekTTL},
+	}
+}
+
+// siteUniqueKey of the weekly sketch counting distinct (post, user) pairs.
+func siteUniqueKey(kind string, at time.Time) string {
+	year, week := at.ISOWeek()
+	return fmt.Sprintf("rates:%s:hll:%d/%d", kind, year, week)
+}
+
+func countSketch(d deps, key string) (int, error) {
+	v, err := d.LedisDB().Get([]byte(key))
+	if err != nil {
+		return 0, err
+	}
+	h := helpers.HyperLogLog(v)
+	if h.Valid() == false {
+		return 0, nil
+	}
+	return int(h.Count()), nil
+}
+
+// FindUniques estimates distinct users that viewed or were reached by a post.
+func FindUniques(d deps, kind string, id bson.ObjectId, now time.Time) (u Uniques, err error) {
+	keys := uniqueKeys(kind, id, now)
+	counts := make([]int, len(keys))
+	for i, k := range keys {
+		counts[i], err = countSketch(d, k.key)
+		if err != nil {
+			return
+		}
+	}
+	u = Uniques{Total: counts[0], Day: counts[1], Week: counts[2]}
+	return
+}
+
+// FindRatedAfter gets rate list entries past given cursor, in cursor direction.
+// Entries sharing the cursor score are skipped until the cursor item itself.
+func FindRatedAfter(d deps, key string, c *Cursor, limit int) ([]Rated, error) {
+	var (
+		list    = []Rated{}
+		min     = int64(math.MinInt64)
+		max     = int64(math.MaxInt64)
+		reverse = true
+		passed  = c == nil
+	)
+	if c != nil && c.Back {
+		min, reverse = c.Score, false
+	} else if c != nil {
+		max = c.Score
+	}
+	chunk := limit + 20
+	for offset := 0; len(list) < limit; offset += chunk {
+		scores, err := d.LedisDB().ZRangeByScoreGeneric([]byte(key), min, max, offset, chunk, reverse)
+		if err != nil {
+			return list, err
+		}
+		for _, n := range scores {
+			id := string(n.Member)
+			if passed == false && n.Score == c.Score {
+				passed = id == c.ID.Hex()
+				continue
+			}
+			passed = true
+			if bson.IsObjectIdHex(id) == false {
+				continue
+			}
+			list = append(list, Rated{bson.ObjectIdHex(id), n.Score})
+			if len(list) == limit {
+				break
+			}
+		}
+		if len(scores) < chunk {
+			break
+		}
+	}
+	return list, nil
+}
+
+// FindRevisions of a post, oldest first.
+func FindRevisions(d deps, postID bson.ObjectId) (list Revisions, err error) {
+	list = Revisions{}
+	err = d.Mgo().C("post_revisions").Find(bson.M{"post_id": postID}).Sort("created_at", "_id").All(&list)
+	return
+}
+
+func FindRevision(d deps, postID, id bson.ObjectId) (r Revision, err error) {
+	err = d.Mgo().C("post_revisions").Find(bson.M{"_id": id, "post_id": postID}).One(&r)
+	if err != nil {
+		err = RevisionNotFound
+	}
+	return
+}
+
+// FindScheduledId post still waiting to be published.
+func FindScheduledId(d deps, id bson.ObjectId) (post Post, err error) {
+	err = d.Mgo().C("posts").Find(bson.M{
+		"_id":        id,
+		"publish_at": bson.M{"$exists": true},
+		"deleted_at": bson.M{"$exists": false},
+	}).One(&post)
+	if err != nil {
+		err = ScheduledNotFound
+	}
+	return
+}
+
+// FindScheduled posts of an author, next to be published first.
+func FindScheduled(d deps, userID bson.ObjectId) (list Posts, err error) {
+	list = Posts{}
+	err = d.Mgo().C("posts").Find(bson.M{
+		"user_id":    userID,
+		"publish_at": bson.M{"$exists": true},
+		"deleted_at": bson.M{"$exists": false},
+	}).Select(bson.M{"content": 0}).Sort("publish_at").All(&list)
+	return
+}
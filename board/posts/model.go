@@ -21,12 +21,23 @@ type Post struct {
 	Following         bool            `bson:"following,omitempty" json:"following,omitempty"`
 	Pinned            bool            `bson:"pinned,omitempty" json:"pinned,omitempty"`
 	Lock              bool            `bson:"lock" json:"lock"`
+	LockReason        string          `bson:"lock_reason,omitempty" json:"lock_reason,omitempty"`
+	LockUntil         *time.Time      `bson:"lock_until,omitempty" json:"lock_until,omitempty"`
+	MergedInto        *bson.ObjectId  `bson:"merged_into,omitempty" json:"merged_into,omitempty"`
+	Archived          *time.Time      `bson:"archived_at,omitempty" json:"archived_at,omitempty"`
+	Reopened          *time.Time      `bson:"reopened_at,omitempty" json:"reopened_at,omitempty"`
 	IsQuestion        bool            `bson:"is_question" json:"is_question"`
 	Solved            bool            `bson:"solved,omitempty" json:"solved,omitempty"`
 	Liked             int             `bson:"liked,omitempty" json:"liked,omitempty"`
+	Votes             map[string]int  `bson:"votes,omitempty" json:"votes,omitempty"`
+	Bookmarks         int             `bson:"bookmarks,omitempty" json:"bookmarks,omitempty"`
+	ViewsBase         *int            `bson:"views_base,omitempty" json:"-"`
+	ReachedBase       *int            `bson:"reached_base,omitempty" json:"-"`
 	Created           time.Time       `bson:"created_at" json:"created_at"`
 	Updated           time.Time       `bson:"updated_at" json:"updated_at"`
 	Deleted           time.Time       `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy         *bson.ObjectId  `bson:"deleted_by,omitempty" json:"-"`
+	PublishAt         *time.Time      `bson:"publish_at,omitempty" json:"publish_at,omitempty"`
 }
 
 type comments struct {
@@ -60,3 +71,31 @@ func (list Posts) Map() map[bson.ObjectId]Post {
 
 	return m
 }
+
+// Revision actions.
+const (
+	RevisionCreate   = "create"
+	RevisionUpdate   = "update"
+	RevisionRollback = "rollback"
+)
+
+// Revision holds the full post title & content after an edit.
+type Revision struct {
+	ID         bson.ObjectId  `bson:"_id,omitempty" json:"id"`
+	PostID     bson.ObjectId  `bson:"post_id" json:"post_id"`
+	UserID     bson.ObjectId  `bson:"user_id" json:"user_id"`
+	Title      string         `bson:"title" json:"title"`
+	Content    string         `bson:"content" json:"content"`
+	Reason     string         `bson:"reason,omitempty" json:"reason,omitempty"`
+	Action     string         `bson:"action" json:"action"`
+	RollbackOf *bson.ObjectId `bson:"rollback_of,omitempty" json:"rollback_of,omitempty"`
+	Created    time.Time      `bson:"created_at" json:"created_at"`
+}
+
+// Text used to compare revisions, title goes first.
+func (r Revision) Text() string {
+	return r.Title + "\n\n" + r.Content
+}
+
+// Revisions list.
+type Revisions []Revision
@@ -2,11 +2,16 @@ package post
 
 import (
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/siddontang/ledisdb/ledis"
 	"github.com/tryanzu/core/board/activity"
+	"github.com/tryanzu/core/board/ranking"
 	"github.com/tryanzu/core/core/common"
+	"github.com/tryanzu/core/core/config"
+	"github.com/tryanzu/core/modules/helpers"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -17,10 +22,10 @@ func TrackView(d deps, id, user bson.ObjectId) (err error) {
 		Event:     "post",
 		UserID:    user,
 	})
-	err = incrCountCache(d, "posts:views:"+id.Hex(), bson.M{
-		"related_id": id,
-		"event":      "post",
-	})
+	if err != nil {
+		return
+	}
+	err = trackUniques(d, "views", []bson.ObjectId{id}, user, time.Now())
 	if err != nil {
 		return
 	}
@@ -36,74 +41,54 @@ func TrackReachedList(d deps, list []bson.ObjectId, user bson.ObjectId) (err err
 	if err != nil {
 		return
 	}
-	for _, r := range list {
-		err = incrCountCache(d, "posts:reached:"+r.Hex(), bson.M{
-			"list":  r,
-			"event": "feed",
-		})
-		if err != nil {
-			return
-		}
+	err = trackUniques(d, "reached", list, user, time.Now())
+	if err != nil {
+		return
 	}
 	return SyncRates(d, "reached", list)
 }
 
-func getRelReachedCount(d deps, at time.Time) int64 {
-	y, w := at.ISOWeek()
-	rateK := []byte("rates:reached:" + strconv.Itoa(y) + "/" + strconv.Itoa(w))
-	if v, err := d.LedisDB().Exists(rateK); err == nil && v > 0 {
-		kv, err := d.LedisDB().Get(rateK)
-		if err != nil {
-			panic(err)
+// trackUniques adds the user to the posts sketches of given kind, along
+// with the (post, user) pair to the site wide weekly sketch.
+func trackUniques(d deps, kind string, list []bson.ObjectId, user bson.ObjectId, at time.Time) error {
+	item := []byte(user.Hex())
+	for _, id := range list {
+		keys := uniqueKeys(kind, id, at)
+		for _, k := range keys {
+			if err := addToSketch(d, k.key, k.ttl, item); err != nil {
+				return err
+			}
 		}
-
-		n, err := strconv.Atoi(string(kv))
-		if err != nil {
-			panic(err)
+		pair := []byte(id.Hex() + user.Hex())
+		if err := addToSketch(d, siteUniqueKey(kind, at), weekTTL, pair); err != nil {
+			return err
 		}
-
-		return int64(n)
-	}
-	week := firstDayOfISOWeek(y, w, at.Location())
-	endOfWeek := week.AddDate(0, 0, 7)
-	n := activity.CountList(d, bson.M{
-		"event":      "feed",
-		"created_at": bson.M{"$gte": week, "$lte": endOfWeek},
-	})
-	err := d.LedisDB().Set([]byte(rateK), []byte(strconv.Itoa(n)))
-	if err != nil {
-		panic(err)
 	}
-	return int64(n)
+	return nil
 }
 
-func getRelViewsCount(d deps, at time.Time) int64 {
-	y, w := at.ISOWeek()
-	rateK := []byte("rates:views:" + strconv.Itoa(y) + "/" + strconv.Itoa(w))
-	if v, err := d.LedisDB().Exists(rateK); err == nil && v > 0 {
-		kv, err := d.LedisDB().Get(rateK)
-		if err != nil {
-			panic(err)
-		}
-
-		n, err := strconv.Atoi(string(kv))
-		if err != nil {
-			panic(err)
-		}
+// Sketches are read, updated and written back, so updates are serialized.
+var sketchMu sync.Mutex
 
-		return int64(n)
-	}
-	week := firstDayOfISOWeek(y, w, at.Location())
-	endOfWeek := week.AddDate(0, 0, 7)
-	n := activity.Count(d, bson.M{
-		"event":      "post",
-		"created_at": bson.M{"$gte": week, "$lte": endOfWeek},
-	})
-	err := d.LedisDB().Set([]byte(rateK), []byte(strconv.Itoa(n)))
+func addToSketch(d deps, key string, ttl int64, item []byte) error {
+	sketchMu.Lock()
+	defer sketchMu.Unlock()
+	k := []byte(key)
+	v, err := d.LedisDB().Get(k)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	h := helpers.HyperLogLog(v)
+	if h.Valid() == false {
+		h = helpers.NewHyperLogLog()
+	}
+	if h.Add(item) == false && v != nil {
+		return nil
 	}
-	return int64(n)
+	if ttl > 0 {
+		return d.LedisDB().SetEX(k, ttl, h)
+	}
+	return d.LedisDB().Set(k, h)
 }
 
 func SyncRates(d deps, kind string, list []bson.ObjectId) error {
@@ -114,40 +99,30 @@ func SyncRates(d deps, kind string, list []bson.ObjectId) error {
 	db := d.LedisDB()
 	now := time.Now()
 	date := now.Format("2006-01-02")
-	y, w := now.ISOWeek()
-	relReached := getRelReachedCount(d, now)
-	relViews := getRelViewsCount(d, now)
-	rateK := []byte("rates:" + kind + ":" + strconv.Itoa(y) + "/" + strconv.Itoa(w))
-	v, err := d.LedisDB().Exists(rateK)
-	if err == nil && v > 0 && len(list) > 0 {
-		count := int64(len(list))
-		_, err = d.LedisDB().IncrBy(rateK, count)
-		if err != nil {
-			panic(err)
-		}
 
-		switch kind {
-		case "views":
-			relViews += count
-		case "reached":
-			relReached += count
-		}
+	// Relative rates are taken against every unique view & reach of the week.
+	relReached, err := countSketch(d, siteUniqueKey("reached", now))
+	if err != nil {
+		return err
+	}
+	relViews, err := countSketch(d, siteUniqueKey("views", now))
+	if err != nil {
+		return err
 	}
 	update := d.Mgo().C("posts").Bulk()
 	scores := []ledis.ScorePair{}
 	for _, post := range posts {
-		var (
-			views   int
-			reached int
-		)
 		id := post.Id.Hex()
-		if n, err := db.Get([]byte("posts:views:" + id)); err == nil {
-			views, _ = strconv.Atoi(string(n))
+		viewers, err := FindUniques(d, "views", post.Id, now)
+		if err != nil {
+			return err
 		}
-		if n, err := db.Get([]byte("posts:reached:" + id)); err == nil {
-			reached, _ = strconv.Atoi(string(n))
+		reachers, err := FindUniques(d, "reached", post.Id, now)
+		if err != nil {
+			return err
 		}
-		update.Update(bson.M{"_id": post.Id}, bson.M{"$set": bson.M{"views": views, "reached": reached}})
+		update.Update(bson.M{"_id": post.Id}, countsUpdate(post, viewers.Total, reachers.Total))
+		views, reached := viewers.Week, reachers.Week
 		if reached == 0 || relReached == 0 || relViews == 0 {
 			continue
 		}
@@ -185,32 +160,251 @@ func SyncRates(d deps, kind string, list []bson.ObjectId) error {
 	return err
 }
 
-func incrCountCache(d deps, key string, query bson.M) error {
-	// Sync post views cache
-	k := []byte(key)
-	v, err := d.LedisDB().Exists(k)
-	if err == nil && v > 0 {
-		_, err = d.LedisDB().Incr(k)
+// SeedCounts of views and reach counted before unique sketches, stored once
+// as the base post counters add unique ones to.
+func SeedCounts(d deps, id bson.ObjectId) error {
+	legacy := func(key string, query bson.M) (int, error) {
+		v, err := d.LedisDB().Get([]byte(key))
+		if err != nil {
+			return 0, err
+		}
+		if v != nil {
+			return strconv.Atoi(string(v))
+		}
+		return activity.Count(d, query), nil
+	}
+	views, err := legacy("posts:views:"+id.Hex(), bson.M{"related_id": id, "event": "post"})
+	if err != nil {
+		return err
+	}
+	reached, err := legacy("posts:reached:"+id.Hex(), bson.M{"list": id, "event": "feed"})
+	if err != nil {
+		return err
+	}
+	err = d.Mgo().C("posts").Update(bson.M{"_id": id, "views_base": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"views_base": views, "reached_base": reached}})
+	if err == mgo.ErrNotFound {
+		// Already seeded.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	post := Post{Id: id, ViewsBase: &views, ReachedBase: &reached}
+	viewers, err := FindUniques(d, "views", id, time.Now())
+	if err != nil {
+		return err
+	}
+	reachers, err := FindUniques(d, "reached", id, time.Now())
+	if err != nil {
 		return err
 	}
-	n := activity.Count(d, query)
-	return d.LedisDB().Set([]byte(key), []byte(strconv.Itoa(n)))
+	return d.Mgo().C("posts").UpdateId(id, countsUpdate(post, viewers.Total, reachers.Total))
 }
 
-func firstDayOfISOWeek(year int, week int, timezone *time.Location) time.Time {
-	date := time.Date(year, 0, 0, 0, 0, 0, 0, timezone)
-	isoYear, isoWeek := date.ISOWeek()
-	for date.Weekday() != time.Monday { // iterate back to Monday
-		date = date.AddDate(0, 0, -1)
-		isoYear, isoWeek = date.ISOWeek()
+// countsUpdate of post views and reach given their unique counts. Posts not
+// seeded yet keep their legacy counters as the floor.
+func countsUpdate(post Post, views, reached int) bson.M {
+	if post.ViewsBase == nil || post.ReachedBase == nil {
+		return bson.M{"$max": bson.M{"views": views, "reached": reached}}
 	}
-	for isoYear < year { // iterate forward to the first day of the first week
-		date = date.AddDate(0, 0, 1)
-		isoYear, isoWeek = date.ISOWeek()
+	return bson.M{"$set": bson.M{"views": *post.ViewsBase + views, "reached": *post.ReachedBase + reached}}
+}
+
+// SyncRanks recomputes the ranking algorithms scores of given posts.
+func SyncRanks(d deps, list []bson.ObjectId) error {
+	posts, err := FindList(d, common.WithinID(list))
+	if err != nil {
+		return err
+	}
+	db := d.LedisDB()
+	now := time.Now()
+	for name, algo := range ranking.Algorithms {
+		key := []byte(RankList(name))
+		scores := []ledis.ScorePair{}
+		stale := [][]byte{}
+		for _, post := range posts {
+			id := []byte(post.Id.Hex())
+			if post.Deleted.IsZero() == false || post.PublishAt != nil || post.Archived != nil || ranking.Within(algo, post.Created, now) == false {
+				stale = append(stale, id)
+				continue
+			}
+			score := algo.Score(signals(d, post), now)
+			scores = append(scores, ledis.ScorePair{
+				Score:  ranking.Scale(score),
+				Member: id,
+			})
+		}
+		if len(stale) > 0 {
+			if _, err := db.ZRem(key, stale...); err != nil {
+				return err
+			}
+		}
+		if len(scores) > 0 {
+			if _, err := db.ZAdd(key, scores...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PruneRanks drops posts no longer ranked from the algorithms lists, those
+// aged out of their window stay there otherwise as nothing syncs them again.
+func PruneRanks(d deps, now time.Time) error {
+	db := d.LedisDB()
+	for name, algo := range ranking.Algorithms {
+		key := []byte(RankList(name))
+		members, err := db.ZRange(key, 0, -1)
+		if err != nil {
+			return err
+		}
+		ids := []bson.ObjectId{}
+		for _, m := range members {
+			if bson.IsObjectIdHex(string(m.Member)) {
+				ids = append(ids, bson.ObjectIdHex(string(m.Member)))
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		ranked, err := FindList(d, common.WithinID(ids))
+		if err != nil {
+			return err
+		}
+		keep := map[string]bool{}
+		for _, post := range ranked {
+			if post.Deleted.IsZero() && post.PublishAt == nil && post.Archived == nil && ranking.Within(algo, post.Created, now) {
+				keep[post.Id.Hex()] = true
+			}
+		}
+		stale := [][]byte{}
+		for _, m := range members {
+			if keep[string(m.Member)] == false {
+				stale = append(stale, m.Member)
+			}
+		}
+		if len(stale) > 0 {
+			if _, err := db.ZRem(key, stale...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func signals(d deps, post Post) ranking.Signals {
+	s := ranking.Signals{
+		Comments:  post.Comments.Count,
+		Bookmarks: post.Bookmarks,
+		Reactions: post.Votes,
+		Created:   post.Created,
+		Weights:   ranking.Weights(config.C.Rules().ReactionWeights()),
 	}
-	for isoWeek < week { // iterate forward to the first day of the given week
-		date = date.AddDate(0, 0, 1)
-		isoYear, isoWeek = date.ISOWeek()
+	if u, err := FindUniques(d, "views", post.Id, time.Now()); err == nil {
+		s.Views = u.Total
+	}
+	if u, err := FindUniques(d, "reached", post.Id, time.Now()); err == nil {
+		s.Reached = u.Total
+	}
+	return s
+}
+
+// TrackRevision stores a post revision. Posts edited for the first time
+// get their state before the edit stored as the original revision.
+func TrackRevision(d deps, before Post, r Revision) (Revision, error) {
+	n, err := d.Mgo().C("post_revisions").Find(bson.M{"post_id": before.Id}).Count()
+	if err != nil {
+		return r, err
+	}
+	if n == 0 {
+		err = d.Mgo().C("post_revisions").Insert(Revision{
+			ID:      bson.NewObjectId(),
+			PostID:  before.Id,
+			UserID:  before.UserId,
+			Title:   before.Title,
+			Content: before.Content,
+			Action:  RevisionCreate,
+			Created: before.Created,
+		})
+		if err != nil {
+			return r, err
+		}
+	}
+	r.ID = bson.NewObjectId()
+	r.PostID = before.Id
+	r.Created = time.Now()
+	err = d.Mgo().C("post_revisions").Insert(&r)
+	return r, err
+}
+
+// Delete post by given user, it stops being pinned as well until restored.
+func Delete(d deps, post Post, by bson.ObjectId) error {
+	return d.Mgo().C("posts").UpdateId(post.Id, bson.M{
+		"$set":    bson.M{"deleted": true, "deleted_at": time.Now(), "deleted_by": by},
+		"$rename": bson.M{"pinned": "deleted_pinned"},
+	})
+}
+
+// Unrank posts from every algorithm list.
+func Unrank(d deps, list []bson.ObjectId) error {
+	members := make([][]byte, len(list))
+	for k, id := range list {
+		members[k] = []byte(id.Hex())
+	}
+	for name := range ranking.Algorithms {
+		if _, err := d.LedisDB().ZRem([]byte(RankList(name)), members...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaxScheduleDays posts can be scheduled ahead.
+const MaxScheduleDays = 90
+
+// Reschedule a post not published yet.
+func Reschedule(d deps, id bson.ObjectId, at time.Time) error {
+	err := d.Mgo().C("posts").Update(bson.M{"_id": id, "publish_at": bson.M{"$exists": true}}, bson.M{
+		"$set": bson.M{"publish_at": at, "updated_at": time.Now()},
+	})
+	if err == mgo.ErrNotFound {
+		return ScheduledNotFound
+	}
+	return err
+}
+
+// Unschedule a post not published yet, removing it for good.
+func Unschedule(d deps, id bson.ObjectId) error {
+	err := d.Mgo().C("posts").Remove(bson.M{"_id": id, "publish_at": bson.M{"$exists": true}})
+	if err == mgo.ErrNotFound {
+		return ScheduledNotFound
+	}
+	return err
+}
+
+// PublishScheduled posts whose publish time has come. Only posts
+// published by this call are returned, so events fire once.
+func PublishScheduled(d deps, now time.Time) (published []bson.ObjectId, err error) {
+	var due Posts
+	err = d.Mgo().C("posts").Find(bson.M{
+		"publish_at": bson.M{"$lte": now},
+	}).Select(bson.M{"_id": 1}).All(&due)
+	if err != nil {
+		return
+	}
+	for _, p := range due {
+		err = d.Mgo().C("posts").Update(bson.M{"_id": p.Id, "publish_at": bson.M{"$exists": true}}, bson.M{
+			"$set":   bson.M{"created_at": now, "updated_at": now},
+			"$unset": bson.M{"publish_at": 1},
+		})
+		if err == mgo.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		published = append(published, p.Id)
 	}
-	return date
+	err = nil
+	return
 }
@@ -0,0 +1,22 @@
+package post
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestCountsUpdate(t *testing.T) {
+	Convey("Post counters add unique counts to their base", t, func() {
+		Convey("Seeded posts follow their unique counts", func() {
+			views, reached := 120, 300
+			post := Post{ViewsBase: &views, ReachedBase: &reached}
+			So(countsUpdate(post, 5, 10), ShouldResemble, bson.M{"$set": bson.M{"views": 125, "reached": 310}})
+		})
+
+		Convey("Posts not seeded yet keep legacy counters as the floor", func() {
+			So(countsUpdate(Post{}, 5, 10), ShouldResemble, bson.M{"$max": bson.M{"views": 5, "reached": 10}})
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package ranking
+
+import (
+	"math"
+	"time"
+)
+
+// Hot decays with time by favoring newer posts in a fixed amount,
+// so older scores never need to be recomputed to fall behind.
+type Hot struct {
+	// Gravity in seconds it takes a post to need 10x the engagement.
+	Gravity float64
+}
+
+func (h Hot) Score(s Signals, now time.Time) float64 {
+	points := float64(s.Quality()) + float64(s.Comments)/2
+	order := math.Log10(math.Max(math.Abs(points), 1))
+	if points < 0 {
+		order = -order
+	}
+	return order + float64(s.Created.Unix())/h.Gravity
+}
+
+func (Hot) Window() time.Duration {
+	return 30 * 24 * time.Hour
+}
+
+// Top posts by quality within a period.
+type Top struct {
+	Period time.Duration
+}
+
+func (Top) Score(s Signals, now time.Time) float64 {
+	return float64(s.Quality())
+}
+
+func (t Top) Window() time.Duration {
+	return t.Period
+}
+
+// Rising posts gather engagement fast while still young.
+type Rising struct {
+	Period time.Duration
+}
+
+func (Rising) Score(s Signals, now time.Time) float64 {
+	engagement := float64(s.Views) + float64(s.Comments)*4 + float64(s.Positive())*2
+	hours := math.Max(now.Sub(s.Created).Hours(), 1)
+	return engagement / hours
+}
+
+func (r Rising) Window() time.Duration {
+	return r.Period
+}
+
+// Controversial posts get plenty of reactions evenly split in favor and against.
+type Controversial struct {
+	Period time.Duration
+}
+
+func (Controversial) Score(s Signals, now time.Time) float64 {
+	return Controversy(float64(s.Positive()), float64(s.Negative()))
+}
+
+func (c Controversial) Window() time.Duration {
+	return c.Period
+}
@@ -0,0 +1,56 @@
+package ranking
+
+import (
+	"math"
+)
+
+// z-score of the 95% confidence level.
+const confidenceZ = 1.96
+
+// Weights of reactions as configured. Reactions without one weigh 1.
+type Weights map[string]float64
+
+// Of reaction by its name.
+func (w Weights) Of(name string) float64 {
+	if v, exists := w[name]; exists {
+		return v
+	}
+	return 1
+}
+
+// Split weighted reactions in favor and against.
+func (w Weights) Split(reactions map[string]int) (up, down float64) {
+	for name, n := range reactions {
+		if n <= 0 {
+			continue
+		}
+		v := w.Of(name) * float64(n)
+		if v > 0 {
+			up += v
+		} else {
+			down -= v
+		}
+	}
+	return
+}
+
+// Confidence lower bound of the Wilson score interval, the share of reactions
+// in favor we can be fairly sure of given how many there are.
+func Confidence(up, down float64) float64 {
+	n := up + down
+	if n <= 0 {
+		return 0
+	}
+	z := confidenceZ
+	p := up / n
+	return (p + z*z/(2*n) - z*math.Sqrt((p*(1-p)+z*z/(4*n))/n)) / (1 + z*z/n)
+}
+
+// Controversy grows with reactions and with how evenly split they are.
+func Controversy(up, down float64) float64 {
+	if up <= 0 || down <= 0 {
+		return 0
+	}
+	balance := math.Min(up, down) / math.Max(up, down)
+	return math.Pow(up+down, balance)
+}
@@ -0,0 +1,75 @@
+package ranking
+
+import (
+	"time"
+)
+
+// Signals a post is ranked by.
+type Signals struct {
+	Views     int
+	Reached   int
+	Comments  int
+	Bookmarks int
+	Reactions map[string]int
+	Created   time.Time
+
+	// Weights tell reactions in favor from the ones against.
+	Weights Weights
+}
+
+// Quality signals: net reactions plus bookmarks, as saving a post is an endorsement.
+func (s Signals) Quality() int {
+	return s.Positive() - s.Negative() + s.Bookmarks
+}
+
+// Positive reactions count.
+func (s Signals) Positive() (n int) {
+	for k, v := range s.Reactions {
+		if s.Weights.Of(k) > 0 && v > 0 {
+			n += v
+		}
+	}
+	return
+}
+
+// Negative reactions count.
+func (s Signals) Negative() (n int) {
+	for k, v := range s.Reactions {
+		if s.Weights.Of(k) < 0 && v > 0 {
+			n += v
+		}
+	}
+	return
+}
+
+// Algorithm ranks posts by their signals, higher scores rank first.
+type Algorithm interface {
+	Score(s Signals, now time.Time) float64
+
+	// Window posts are ranked within, counted from their creation.
+	Window() time.Duration
+}
+
+// Algorithms available to sort the feed.
+var Algorithms = map[string]Algorithm{
+	"hot":           Hot{Gravity: 45000},
+	"top":           Top{Period: 7 * 24 * time.Hour},
+	"rising":        Rising{Period: 24 * time.Hour},
+	"controversial": Controversial{Period: 30 * 24 * time.Hour},
+}
+
+// Get algorithm by its name.
+func Get(name string) (Algorithm, bool) {
+	a, exists := Algorithms[name]
+	return a, exists
+}
+
+// Scale a score to fit sorted set integer scores.
+func Scale(score float64) int64 {
+	return int64(score * 10000)
+}
+
+// Within tells whether a post created at given time is ranked by the algorithm.
+func Within(a Algorithm, created, now time.Time) bool {
+	return now.Sub(created) <= a.Window()
+}
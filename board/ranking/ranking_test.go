@@ -0,0 +1,86 @@
+package ranking
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var weights = Weights{"useful": 2, "concise": 1, "offtopic": -1, "wordy": -0.5}
+
+func TestAlgorithms(t *testing.T) {
+	now := time.Now()
+	Convey("Posts are ranked by their signals", t, func() {
+		Convey("Reactions are split by their kind", func() {
+			s := Signals{Reactions: map[string]int{"useful": 3, "concise": 1, "offtopic": 2}, Weights: weights}
+			So(s.Positive(), ShouldEqual, 4)
+			So(s.Negative(), ShouldEqual, 2)
+		})
+
+		Convey("Reactions without a configured weight count in favor", func() {
+			s := Signals{Reactions: map[string]int{"useful": 1, "offtopic": 2}}
+			So(s.Positive(), ShouldEqual, 3)
+			So(s.Negative(), ShouldEqual, 0)
+		})
+
+		Convey("Hot favors newer posts with the same engagement", func() {
+			hot := Algorithms["hot"]
+			r := map[string]int{"useful": 10}
+			older := Signals{Reactions: r, Created: now.Add(-48 * time.Hour)}
+			newer := Signals{Reactions: r, Created: now.Add(-time.Hour)}
+			So(hot.Score(newer, now), ShouldBeGreaterThan, hot.Score(older, now))
+		})
+
+		Convey("Hot needs 10x the engagement to make up for its gravity", func() {
+			hot := Hot{Gravity: 3600}
+			older := Signals{Reactions: map[string]int{"useful": 100}, Created: now.Add(-2 * time.Hour)}
+			newer := Signals{Reactions: map[string]int{"useful": 10}, Created: now.Add(-time.Hour)}
+			So(hot.Score(older, now), ShouldAlmostEqual, hot.Score(newer, now), 0.0001)
+		})
+
+		Convey("Top ranks by net reactions and bookmarks within its period", func() {
+			top := Top{Period: 24 * time.Hour}
+			s := Signals{Reactions: map[string]int{"useful": 5, "wordy": 2}, Bookmarks: 2, Created: now.Add(-48 * time.Hour), Weights: weights}
+			So(top.Score(s, now), ShouldEqual, 5)
+			So(Within(top, s.Created, now), ShouldBeFalse)
+			So(Within(top, now.Add(-time.Hour), now), ShouldBeTrue)
+		})
+
+		Convey("Rising ranks by engagement per hour", func() {
+			rising := Rising{Period: 24 * time.Hour}
+			fast := Signals{Views: 100, Created: now.Add(-time.Hour)}
+			slow := Signals{Views: 100, Created: now.Add(-10 * time.Hour)}
+			So(rising.Score(fast, now), ShouldEqual, 100)
+			So(rising.Score(slow, now), ShouldEqual, 10)
+		})
+
+		Convey("Controversial favors evenly split reactions", func() {
+			c := Algorithms["controversial"]
+			split := Signals{Reactions: map[string]int{"useful": 10, "offtopic": 10}, Weights: weights}
+			skewed := Signals{Reactions: map[string]int{"useful": 18, "offtopic": 2}, Weights: weights}
+			liked := Signals{Reactions: map[string]int{"useful": 20}, Weights: weights}
+			So(c.Score(split, now), ShouldEqual, 20)
+			So(c.Score(split, now), ShouldBeGreaterThan, c.Score(skewed, now))
+			So(c.Score(liked, now), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestConfidence(t *testing.T) {
+	Convey("Comments are scored by weighted reactions", t, func() {
+		Convey("Configured weights override the default one", func() {
+			w := Weights{"useful": 2, "wordy": -0.5}
+			up, down := w.Split(map[string]int{"useful": 3, "concise": 1, "wordy": 2, "offtopic": 1})
+			So(up, ShouldEqual, 8)
+			So(down, ShouldEqual, 1)
+		})
+
+		Convey("Confidence favors more reactions with the same share in favor", func() {
+			So(Confidence(10, 0), ShouldBeGreaterThan, Confidence(1, 0))
+			So(Confidence(100, 10), ShouldBeGreaterThan, Confidence(10, 1))
+			So(Confidence(0, 0), ShouldEqual, 0)
+			So(Confidence(5, 0), ShouldBeLessThan, 1)
+		})
+	})
+}
@@ -252,7 +252,18 @@ func (c *Client) readChatMessage(e SocketEvent) {
 		"at":     time.Now(),
 		"id":     mid,
 	}
+	// Chat messages can't be held for review, so review filters block them.
 	pre, err := content.Preprocess(deps.Container, chatM)
+	if _, blocked := err.(content.Blocked); blocked {
+		c.SafeWrite(SocketEvent{
+			Event: "error",
+			Params: map[string]interface{}{
+				"chan": channel,
+				"msg":  err.Error(),
+			},
+		}.encode())
+		return
+	}
 	if err != nil {
 		log.Errorf("could not preprocess chat message, err: %v", err)
 		return
@@ -390,6 +401,7 @@ func (c *Client) sysFlag(reason string) {
 		RelatedTo: "chat",
 		Content:   "System has sent this flag.",
 		Reason:    reason,
+		System:    true,
 	})
 	if err != nil {
 		log.Errorf("sysFlag failed, userId = %s | relatedTo: chat | reason: %s", c.User.Id, reason)
@@ -9,6 +9,7 @@ import (
 	"github.com/desertbit/glue"
 	"github.com/henrylee2cn/goutil"
 	"github.com/op/go-logging"
+	"github.com/tryanzu/core/board/emojis"
 	"github.com/tryanzu/core/core/config"
 	"github.com/tryanzu/core/deps"
 	"gopkg.in/mgo.v2/bson"
@@ -171,11 +172,7 @@ func onNewSocket(s *glue.Socket) {
 		client.Read <- event
 	})
 
-	runtime := config.C.Copy()
-	conf, err := json.Marshal(map[string]interface{}{
-		"event":  "config",
-		"params": runtime.Site,
-	})
+	conf, err := configEvent()
 	if err != nil {
 		log.Criticalf("could not marshal site config		err=%v", err)
 		return
@@ -183,7 +180,7 @@ func onNewSocket(s *glue.Socket) {
 
 	// Send a welcome string to the client.
 	s.Write(`{"event": "connected"}`)
-	s.Write(string(conf))
+	s.Write(conf)
 
 	sockets.Store(s.ID(), client)
 }
@@ -198,24 +195,50 @@ func ServeHTTP() func(w http.ResponseWriter, r *http.Request) {
 	go func() {
 		for {
 			<-config.C.Reload
-			runtime := config.C.Copy()
-			conf, err := json.Marshal(map[string]interface{}{
-				"event":  "config",
-				"params": runtime.Site,
-			})
 			log.SetBackend(config.LoggingBackend)
-			if err != nil {
-				log.Criticalf("cound not marshal site configuration		err=%v", err)
-				continue
-			}
-
-			Broadcast <- string(conf)
+			BroadcastConfig()
 		}
 	}()
 
 	return server.ServeHTTP
 }
 
+// BroadcastConfig sends current site config to every connected client.
+func BroadcastConfig() {
+	conf, err := configEvent()
+	if err != nil {
+		log.Criticalf("cound not marshal site configuration		err=%v", err)
+		return
+	}
+
+	Broadcast <- string(conf)
+}
+
+// configEvent holds site config along with custom emojis so clients can autocomplete them.
+func configEvent() (string, error) {
+	runtime := config.C.Copy()
+	site, err := json.Marshal(runtime.Site)
+	if err != nil {
+		return "", err
+	}
+	params := map[string]interface{}{}
+	err = json.Unmarshal(site, &params)
+	if err != nil {
+		return "", err
+	}
+	list, err := emojis.FindList(deps.Container)
+	if err != nil {
+		log.Errorf("could not load emojis for config		err=%v", err)
+		list = emojis.Emojis{}
+	}
+	params["emojis"] = list
+	conf, err := json.Marshal(map[string]interface{}{
+		"event":  "config",
+		"params": params,
+	})
+	return string(conf), err
+}
+
 func elapsed(name string) func() {
 	starts := time.Now()
 	return func() {
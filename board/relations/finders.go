@@ -0,0 +1,52 @@
+package relations
+
+import (
+	"errors"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var RelationNotFound = errors.New("Relation has not been found by given criteria.")
+
+// FindList of user relations of given kind, latest first.
+func FindList(d deps, userID bson.ObjectId, kind string) (list Relations, err error) {
+	list = Relations{}
+	err = d.Mgo().C("relations").Find(bson.M{"user_id": userID, "kind": kind}).Sort("-created_at").All(&list)
+	return
+}
+
+// FindRelated ids of given type the user has a relation of given kind with.
+func FindRelated(d deps, userID bson.ObjectId, kind, related string) ([]bson.ObjectId, error) {
+	var list Relations
+	err := d.Mgo().C("relations").Find(bson.M{"user_id": userID, "kind": kind, "related": related}).Select(bson.M{"related_id": 1}).All(&list)
+	ids := make([]bson.ObjectId, len(list))
+	for n, r := range list {
+		ids[n] = r.RelatedID
+	}
+	return ids, err
+}
+
+// FindOne relation of the user.
+func FindOne(d deps, userID bson.ObjectId, kind, related string, id bson.ObjectId) (r Relation, err error) {
+	err = d.Mgo().C("relations").Find(bson.M{
+		"user_id":    userID,
+		"kind":       kind,
+		"related":    related,
+		"related_id": id,
+	}).One(&r)
+	if err != nil {
+		err = RelationNotFound
+	}
+	return
+}
+
+// FindUsers having a relation of given kind with an item.
+func FindUsers(d deps, kind, related string, id bson.ObjectId) ([]bson.ObjectId, error) {
+	var list Relations
+	err := d.Mgo().C("relations").Find(bson.M{"kind": kind, "related": related, "related_id": id}).Select(bson.M{"user_id": 1}).All(&list)
+	ids := make([]bson.ObjectId, len(list))
+	for n, r := range list {
+		ids[n] = r.UserID
+	}
+	return ids, err
+}
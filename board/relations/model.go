@@ -0,0 +1,44 @@
+package relations
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Relation kinds.
+const (
+	Follow = "follow"
+	Mute   = "mute"
+	Block  = "block"
+)
+
+// allowed related types for each relation kind.
+var allowed = map[string][]string{
+	Follow: {"user", "post"},
+	Mute:   {"user", "category", "post"},
+	Block:  {"user"},
+}
+
+// Relation of a user with another user, post or category.
+type Relation struct {
+	ID        bson.ObjectId `bson:"_id,omitempty" json:"id"`
+	UserID    bson.ObjectId `bson:"user_id" json:"user_id"`
+	Kind      string        `bson:"kind" json:"kind"`
+	Related   string        `bson:"related" json:"related"`
+	RelatedID bson.ObjectId `bson:"related_id" json:"related_id"`
+	Created   time.Time     `bson:"created_at" json:"created_at"`
+}
+
+// Relations list.
+type Relations []Relation
+
+// Valid kind of relation with given related type.
+func Valid(kind, related string) bool {
+	for _, r := range allowed[kind] {
+		if r == related {
+			return true
+		}
+	}
+	return false
+}
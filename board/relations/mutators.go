@@ -0,0 +1,60 @@
+package relations
+
+import (
+	"errors"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var InvalidRelation = errors.New("Invalid relation kind for given related type.")
+
+// Relate user with given item. Relating twice keeps the original relation.
+func Relate(d deps, userID bson.ObjectId, kind, related string, id bson.ObjectId) (Relation, error) {
+	if Valid(kind, related) == false || (related == "user" && id == userID) {
+		return Relation{}, InvalidRelation
+	}
+	if r, err := FindOne(d, userID, kind, related, id); err == nil {
+		return r, nil
+	}
+	// Following and muting a thread exclude each other.
+	if related == "post" {
+		other := Mute
+		if kind == Mute {
+			other = Follow
+		}
+		if err := Unrelate(d, userID, other, related, id); err != nil {
+			return Relation{}, err
+		}
+	}
+	r := Relation{
+		ID:        bson.NewObjectId(),
+		UserID:    userID,
+		Kind:      kind,
+		Related:   related,
+		RelatedID: id,
+		Created:   time.Now(),
+	}
+	err := d.Mgo().C("relations").Insert(&r)
+	return r, err
+}
+
+// Unrelate user from given item.
+func Unrelate(d deps, userID bson.ObjectId, kind, related string, id bson.ObjectId) error {
+	_, err := d.Mgo().C("relations").RemoveAll(bson.M{
+		"user_id":    userID,
+		"kind":       kind,
+		"related":    related,
+		"related_id": id,
+	})
+	return err
+}
+
+// AutoFollow a post on behalf of the user, unless they muted it before.
+func AutoFollow(d deps, userID, postID bson.ObjectId) error {
+	if _, err := FindOne(d, userID, Mute, "post", postID); err == nil {
+		return nil
+	}
+	_, err := Relate(d, userID, Follow, "post", postID)
+	return err
+}
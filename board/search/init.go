@@ -40,6 +40,7 @@ func (a users) Less(i, j int) bool { return a[i].Score > a[j].Score }
 func prepare() {
 	log.SetBackend(config.LoggingBackend)
 	log.Info("service starting...")
+	go hydratePosts()
 	go func() {
 		for {
 			var user User
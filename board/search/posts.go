@@ -0,0 +1,109 @@
+package search
+
+import (
+	"sync"
+	"time"
+
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/deps"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	postsBufferSize = 5000
+	minSimilarity   = 0.1
+)
+
+var postsIndex = newTfidf()
+var postsMeta = struct {
+	sync.RWMutex
+	m map[string]Post
+}{m: map[string]Post{}}
+
+// Post similar to a title or another post.
+type Post struct {
+	ID       bson.ObjectId `json:"id"`
+	Title    string        `json:"title"`
+	Slug     string        `json:"slug"`
+	Category bson.ObjectId `json:"category"`
+	Created  time.Time     `json:"created_at"`
+	Score    float64       `json:"score"`
+}
+
+// postTerms weights titles and tags over content, as they summarize the post.
+func postTerms(p posts.Post) map[string]float64 {
+	tf := terms(p.Title, 3, nil)
+	for _, tag := range p.Categories {
+		terms(tag, 2, tf)
+	}
+	content := []rune(p.Content)
+	if len(content) > 3000 {
+		content = content[:3000]
+	}
+	return terms(string(content), 1, tf)
+}
+
+// IndexPost terms, replacing previous ones. Unpublished posts are left out.
+func IndexPost(p posts.Post) {
+	if p.Deleted.IsZero() == false || p.PublishAt != nil || p.MergedInto != nil {
+		UnindexPost(p.Id)
+		return
+	}
+	id := p.Id.Hex()
+	postsMeta.Lock()
+	postsMeta.m[id] = Post{ID: p.Id, Title: p.Title, Slug: p.Slug, Category: p.Category, Created: p.Created}
+	postsMeta.Unlock()
+	postsIndex.add(id, postTerms(p))
+}
+
+// UnindexPost so it is no longer suggested.
+func UnindexPost(id bson.ObjectId) {
+	postsIndex.remove(id.Hex())
+	postsMeta.Lock()
+	delete(postsMeta.m, id.Hex())
+	postsMeta.Unlock()
+}
+
+// SimilarPosts to a title, such as possible duplicates of a new post.
+func SimilarPosts(title string, limit int) []Post {
+	return matches(postsIndex.similar(terms(title, 1, nil), "", minSimilarity, limit))
+}
+
+// RelatedPosts to given post by title, tags and content.
+func RelatedPosts(p posts.Post, limit int) []Post {
+	return matches(postsIndex.similar(postTerms(p), p.Id.Hex(), minSimilarity, limit))
+}
+
+func matches(list []scored) []Post {
+	postsMeta.RLock()
+	defer postsMeta.RUnlock()
+	m := []Post{}
+	for _, s := range list {
+		p, exists := postsMeta.m[s.id]
+		if !exists {
+			continue
+		}
+		p.Score = s.score
+		m = append(m, p)
+	}
+	return m
+}
+
+func hydratePosts() {
+	var p posts.Post
+	iter := deps.Container.Mgo().C("posts").Find(bson.M{
+		"deleted_at": bson.M{"$exists": false},
+		"publish_at": bson.M{"$exists": false},
+	}).Sort("-created_at").Limit(postsBufferSize).Iter()
+	n := 0
+	for iter.Next(&p) {
+		IndexPost(p)
+		p = posts.Post{}
+		n++
+	}
+	if err := iter.Close(); err != nil {
+		log.Error(err)
+		return
+	}
+	log.Infof("in-memory posts similarity index has been hydrated	count=%v", n)
+}
@@ -0,0 +1,140 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Words too common to tell documents apart.
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`
+		que los las del por una para con como pero mas sus este esta esto eso
+		son hay ser fue han muy sin sobre tambien entre cuando todo todos ya
+		donde quien porque cual alguien algun alguna tengo tiene puedo puede
+		the and for with that this from are was you have not but how what why`) {
+		stopwords[w] = true
+	}
+}
+
+var unaccent = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u")
+
+// terms frequency of a text, weighted by given factor.
+func terms(text string, weight float64, into map[string]float64) map[string]float64 {
+	if into == nil {
+		into = map[string]float64{}
+	}
+	text = unaccent.Replace(strings.ToLower(text))
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsLetter(r) == false && unicode.IsNumber(r) == false
+	})
+	for _, w := range words {
+		if len([]rune(w)) < 3 || stopwords[w] {
+			continue
+		}
+		into[w] += weight
+	}
+	return into
+}
+
+// tfidf index of documents terms, safe for concurrent use.
+type tfidf struct {
+	sync.RWMutex
+	docs     map[string]map[string]float64
+	postings map[string]map[string]struct{}
+}
+
+func newTfidf() *tfidf {
+	return &tfidf{
+		docs:     map[string]map[string]float64{},
+		postings: map[string]map[string]struct{}{},
+	}
+}
+
+type scored struct {
+	id    string
+	score float64
+}
+
+// add document terms, replacing previous ones.
+func (t *tfidf) add(id string, tf map[string]float64) {
+	t.Lock()
+	defer t.Unlock()
+	t.unlink(id)
+	t.docs[id] = tf
+	for term := range tf {
+		if t.postings[term] == nil {
+			t.postings[term] = map[string]struct{}{}
+		}
+		t.postings[term][id] = struct{}{}
+	}
+}
+
+func (t *tfidf) remove(id string) {
+	t.Lock()
+	defer t.Unlock()
+	t.unlink(id)
+}
+
+func (t *tfidf) unlink(id string) {
+	for term := range t.docs[id] {
+		delete(t.postings[term], id)
+		if len(t.postings[term]) == 0 {
+			delete(t.postings, term)
+		}
+	}
+	delete(t.docs, id)
+}
+
+func (t *tfidf) idf(term string) float64 {
+	return math.Log(1 + float64(len(t.docs))/float64(1+len(t.postings[term])))
+}
+
+func (t *tfidf) norm(tf map[string]float64) (n float64) {
+	for term, f := range tf {
+		w := f * t.idf(term)
+		n += w * w
+	}
+	return math.Sqrt(n)
+}
+
+// similar documents to given terms by cosine similarity, best first.
+func (t *tfidf) similar(tf map[string]float64, skip string, min float64, limit int) []scored {
+	t.RLock()
+	defer t.RUnlock()
+	qnorm := t.norm(tf)
+	if qnorm == 0 {
+		return []scored{}
+	}
+	dots := map[string]float64{}
+	for term, f := range tf {
+		idf := t.idf(term)
+		for id := range t.postings[term] {
+			if id == skip {
+				continue
+			}
+			dots[id] += f * idf * t.docs[id][term] * idf
+		}
+	}
+	list := []scored{}
+	for id, dot := range dots {
+		score := dot / (qnorm * t.norm(t.docs[id]))
+		if score >= min {
+			list = append(list, scored{id, score})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].score == list[j].score {
+			return list[i].id > list[j].id
+		}
+		return list[i].score > list[j].score
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
@@ -0,0 +1,41 @@
+package search
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTfidf(t *testing.T) {
+	Convey("Documents are matched by their shared terms", t, func() {
+		idx := newTfidf()
+		idx.add("a", terms("Problema con la tarjeta de video nvidia", 1, nil))
+		idx.add("b", terms("Que fuente de poder comprar para mi pc", 1, nil))
+		idx.add("c", terms("Tarjeta de video nvidia se calienta mucho", 1, nil))
+
+		Convey("Terms are lowercased, unaccented and stopwords dropped", func() {
+			tf := terms("Cómo instalar los drivers de la Cámara, instalar?", 2, nil)
+			So(tf, ShouldResemble, map[string]float64{"instalar": 4, "drivers": 2, "camara": 2})
+		})
+
+		Convey("Best matches come first and unrelated ones are left out", func() {
+			list := idx.similar(terms("mi tarjeta de video nvidia falla", 1, nil), "", 0.1, 5)
+			So(len(list), ShouldEqual, 2)
+			So(list[0].id, ShouldEqual, "a")
+			So(list[1].id, ShouldEqual, "c")
+		})
+
+		Convey("The document itself can be skipped", func() {
+			list := idx.similar(idx.docs["a"], "a", 0.1, 5)
+			So(len(list), ShouldEqual, 1)
+			So(list[0].id, ShouldEqual, "c")
+		})
+
+		Convey("Removed and replaced documents no longer match their old terms", func() {
+			idx.remove("c")
+			idx.add("a", terms("Fuente de poder para pc gamer", 1, nil))
+			So(idx.similar(terms("tarjeta nvidia", 1, nil), "", 0.1, 5), ShouldBeEmpty)
+			So(len(idx.postings["nvidia"]), ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,281 @@
+package threads
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tryanzu/core/board/comments"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/core/common"
+	"github.com/tryanzu/core/modules/helpers"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	InvalidMove  = errors.New("Invalid category to move the post into.")
+	InvalidMerge = errors.New("Invalid posts to merge, they must be different and published.")
+	InvalidSplit = errors.New("Invalid split, pick top level comments of the post and a title.")
+)
+
+// Move post to another category, fixing unread category counters.
+func Move(d deps, post posts.Post, category bson.ObjectId) error {
+	if post.Category == category {
+		return InvalidMove
+	}
+	from, err := categorySlug(d, post.Category)
+	if err != nil {
+		return err
+	}
+	// Posts only live in subcategories, parent ones just group them.
+	var target struct {
+		Slug string `bson:"slug"`
+	}
+	err = d.Mgo().C("categories").Find(bson.M{"_id": category, "parent": bson.M{"$exists": true}}).Select(bson.M{"slug": 1}).One(&target)
+	if err != nil {
+		return InvalidMove
+	}
+	to := target.Slug
+	err = d.Mgo().C("posts").UpdateId(post.Id, bson.M{"$set": bson.M{"category": category}})
+	if err != nil {
+		return err
+	}
+
+	// Same counters scheme as new posts, the post is now unread in the new
+	// category only.
+	err = countUnread(d, from, post, -1)
+	if err != nil {
+		return err
+	}
+	_, err = d.Mgo().C("counters").UpdateAll(nil, bson.M{"$inc": bson.M{counterField(to): 1}})
+	return err
+}
+
+// Merge folds the comments of a post into another one. The merged post is
+// deleted and keeps a pointer so its slug can redirect into the other one.
+func Merge(d deps, from, into posts.Post) error {
+	if from.Id == into.Id || from.Deleted.IsZero() == false || into.Deleted.IsZero() == false {
+		return InvalidMerge
+	}
+	_, err := d.Mgo().C("comments").UpdateAll(
+		bson.M{"reply_type": "post", "reply_to": from.Id},
+		bson.M{"$set": bson.M{"reply_to": into.Id, "post_id": into.Id}},
+	)
+	if err != nil {
+		return err
+	}
+	_, err = d.Mgo().C("comments").UpdateAll(bson.M{"post_id": from.Id}, bson.M{"$set": bson.M{"post_id": into.Id}})
+	if err != nil {
+		return err
+	}
+	err = d.Mgo().C("posts").UpdateId(into.Id, bson.M{
+		"$inc":      bson.M{"comments.count": from.Comments.Count},
+		"$addToSet": bson.M{"users": bson.M{"$each": append(from.Users, from.UserId)}},
+		"$set":      bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return err
+	}
+	return d.Mgo().C("posts").UpdateId(from.Id, bson.M{
+		"$set":   bson.M{"deleted_at": time.Now(), "merged_into": into.Id},
+		"$unset": bson.M{"pinned": ""},
+	})
+}
+
+// Split turns top level comments of a post into a new post. The earliest
+// comment becomes the new post content and its replies become its comments.
+func Split(d deps, post posts.Post, ids []bson.ObjectId, title string, category bson.ObjectId) (split posts.Post, err error) {
+	title = strings.TrimSpace(title)
+	if len(title) == 0 || len(ids) == 0 {
+		return split, InvalidSplit
+	}
+	if len([]rune(title)) > 72 {
+		title = helpers.Truncate(title, 72) + "..."
+	}
+	list, err := comments.FindList(d, common.WithinID(ids), common.SoftDelete)
+	if err != nil {
+		return
+	}
+	picked := comments.Comments{}
+	for _, c := range list {
+		if c.ReplyType == "post" && c.ReplyTo == post.Id {
+			picked = append(picked, c)
+		}
+	}
+	if len(picked) == 0 || len(picked) != len(ids) {
+		return split, InvalidSplit
+	}
+	sort.Slice(picked, func(i, j int) bool {
+		return picked[i].Created.Before(picked[j].Created)
+	})
+	first, rest := picked[0], picked[1:]
+	users := []bson.ObjectId{first.UserId}
+	for _, c := range rest {
+		users = append(users, c.UserId)
+	}
+	slug := helpers.StrSlug(title)
+	if n, _ := d.Mgo().C("posts").Find(bson.M{"slug": slug}).Count(); n > 0 {
+		slug = helpers.StrSlugRandom(title)
+	}
+	split = posts.Post{
+		Id:       bson.NewObjectId(),
+		Title:    title,
+		Slug:     slug,
+		Type:     post.Type,
+		Content:  first.Content,
+		Category: category,
+		UserId:   first.UserId,
+		Users:    users,
+		Created:  time.Now(),
+		Updated:  time.Now(),
+	}
+
+	// Replies to the first comment are now replies to the new post itself.
+	var promoted comments.Comments
+	err = d.Mgo().C("comments").Find(bson.M{
+		"reply_type": "comment",
+		"reply_to":   first.Id,
+		"deleted_at": bson.M{"$exists": false},
+	}).Select(bson.M{"_id": 1}).All(&promoted)
+	if err != nil {
+		return
+	}
+	split.Comments.Count = len(rest) + len(promoted)
+	err = d.Mgo().C("posts").Insert(&split)
+	if err != nil {
+		return
+	}
+	moved := append(promoted.IDList(), rest.IDList()...)
+	_, err = d.Mgo().C("comments").UpdateAll(
+		bson.M{"_id": bson.M{"$in": moved}},
+		bson.M{"$set": bson.M{"reply_type": "post", "reply_to": split.Id, "post_id": split.Id}},
+	)
+	if err != nil {
+		return
+	}
+
+	// Nested replies follow their comments, level by level.
+	for level := moved; len(level) > 0; {
+		var replies comments.Comments
+		err = d.Mgo().C("comments").Find(bson.M{
+			"post_id":    post.Id,
+			"reply_type": "comment",
+			"reply_to":   bson.M{"$in": level},
+		}).Select(bson.M{"_id": 1}).All(&replies)
+		if err != nil {
+			return
+		}
+		level = replies.IDList()
+		if len(level) == 0 {
+			break
+		}
+		_, err = d.Mgo().C("comments").UpdateAll(bson.M{"_id": bson.M{"$in": level}}, bson.M{"$set": bson.M{"post_id": split.Id}})
+		if err != nil {
+			return
+		}
+	}
+
+	// The first comment is no longer part of its replies tree.
+	_, err = d.Mgo().C("comments").UpdateAll(bson.M{"ancestors": first.Id}, bson.M{"$pull": bson.M{"ancestors": first.Id}})
+	if err != nil {
+		return
+	}
+
+	err = d.Mgo().C("comments").UpdateId(first.Id, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	if err != nil {
+		return
+	}
+	err = d.Mgo().C("posts").UpdateId(post.Id, bson.M{"$inc": bson.M{"comments.count": -len(picked)}})
+	return
+}
+
+// Close post to new comments with a reason, optionally until given time.
+func Close(d deps, post posts.Post, reason string, until *time.Time) error {
+	set := bson.M{"lock": true, "lock_reason": reason}
+	update := bson.M{"$set": set}
+	if until != nil {
+		set["lock_until"] = *until
+	} else {
+		update["$unset"] = bson.M{"lock_until": ""}
+	}
+	return d.Mgo().C("posts").UpdateId(post.Id, update)
+}
+
+// Reopen a closed or archived post. Its inactivity policies start counting
+// again from now on.
+func Reopen(d deps, post posts.Post) error {
+	err := d.Mgo().C("posts").UpdateId(post.Id, bson.M{
+		"$set":   bson.M{"lock": false, "reopened_at": time.Now()},
+		"$unset": bson.M{"lock_reason": "", "lock_until": "", "archived_at": ""},
+	})
+	if err != nil || post.Archived == nil {
+		return err
+	}
+	return posts.SyncRanks(d, []bson.ObjectId{post.Id})
+}
+
+// ReopenExpired posts whose closing time has come. Only posts reopened
+// by this call are returned.
+func ReopenExpired(d deps, now time.Time) (reopened []bson.ObjectId, err error) {
+	var due posts.Posts
+	err = d.Mgo().C("posts").Find(bson.M{"lock": true, "lock_until": bson.M{"$lte": now}}).Select(bson.M{"_id": 1, "lock_until": 1}).All(&due)
+	if err != nil {
+		return
+	}
+	for _, p := range due {
+		err = d.Mgo().C("posts").Update(bson.M{"_id": p.Id, "lock_until": p.LockUntil}, bson.M{
+			"$set":   bson.M{"lock": false},
+			"$unset": bson.M{"lock_reason": "", "lock_until": ""},
+		})
+		if err == mgo.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		reopened = append(reopened, p.Id)
+	}
+	err = nil
+	return
+}
+
+// CountUnread adds n to the unread counter of the post category, as
+// deleting or restoring the post does. Users who reset the counter after
+// the post was created had it read already, so theirs is left alone.
+func CountUnread(d deps, post posts.Post, n int) error {
+	slug, err := categorySlug(d, post.Category)
+	if err != nil {
+		return err
+	}
+	return countUnread(d, slug, post, n)
+}
+
+func countUnread(d deps, slug string, post posts.Post, n int) error {
+	criteria := bson.M{"$or": []bson.M{
+		{counterUpdatedField(slug): bson.M{"$lt": post.Created}},
+		{counterUpdatedField(slug): bson.M{"$exists": false}},
+	}}
+	if n < 0 {
+		criteria[counterField(slug)] = bson.M{"$gt": 0}
+	}
+	_, err := d.Mgo().C("counters").UpdateAll(criteria, bson.M{"$inc": bson.M{counterField(slug): n}})
+	return err
+}
+
+func categorySlug(d deps, id bson.ObjectId) (string, error) {
+	var category struct {
+		Slug string `bson:"slug"`
+	}
+	err := d.Mgo().C("categories").FindId(id).Select(bson.M{"slug": 1}).One(&category)
+	return category.Slug, err
+}
+
+func counterField(slug string) string {
+	return "counters." + strings.Replace(slug, "-", "_", -1) + ".counter"
+}
+
+func counterUpdatedField(slug string) string {
+	return "counters." + strings.Replace(slug, "-", "_", -1) + ".updated_at"
+}
@@ -0,0 +1,116 @@
+package threads
+
+import (
+	"time"
+
+	"github.com/tryanzu/core/board/categories"
+	posts "github.com/tryanzu/core/board/posts"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Policy actions taken on inactive posts.
+const (
+	AutoLock    = "auto-lock"
+	AutoArchive = "auto-archive"
+)
+
+// Reasons shown on posts closed by a policy.
+const (
+	LockedReason   = "Locked after a period without activity."
+	ArchivedReason = "Archived after a long period without activity."
+)
+
+// At most this many posts are enforced per category and rule each run.
+const policyBatch = 500
+
+// Enforced policy action on a post.
+type Enforced struct {
+	Post     bson.ObjectId
+	Category bson.ObjectId
+	Action   string
+	Reason   string
+}
+
+// EnforcePolicies of every category on its inactive posts. Archiving goes
+// first so long inactive posts are archived straight away. Pinned posts are
+// left alone, and posts reopened by a moderator count as active since then.
+func EnforcePolicies(d deps, now time.Time) (list []Enforced, err error) {
+	list = []Enforced{}
+	cats, err := categories.FindWithPolicy(d)
+	if err != nil {
+		return
+	}
+	for _, c := range cats {
+		if c.Policy.ArchiveAfter > 0 {
+			var ids []bson.ObjectId
+			ids, err = Archive(d, c.ID, now.AddDate(0, -c.Policy.ArchiveAfter, 0), now)
+			if err != nil {
+				return
+			}
+			for _, id := range ids {
+				list = append(list, Enforced{id, c.ID, AutoArchive, ArchivedReason})
+			}
+		}
+		if c.Policy.LockAfter > 0 {
+			var ids []bson.ObjectId
+			ids, err = LockInactive(d, c.ID, now.AddDate(0, 0, -c.Policy.LockAfter))
+			if err != nil {
+				return
+			}
+			for _, id := range ids {
+				list = append(list, Enforced{id, c.ID, AutoLock, LockedReason})
+			}
+		}
+	}
+	return
+}
+
+// LockInactive posts of a category without activity since given time.
+func LockInactive(d deps, category bson.ObjectId, since time.Time) ([]bson.ObjectId, error) {
+	criteria := inactive(category, since)
+	criteria["lock"] = bson.M{"$ne": true}
+	return enforce(d, criteria, bson.M{
+		"$set":   bson.M{"lock": true, "lock_reason": LockedReason},
+		"$unset": bson.M{"lock_until": ""},
+	})
+}
+
+// Archive posts of a category without activity since given time. Archived
+// posts are locked and left out of feeds, ranked ones included.
+func Archive(d deps, category bson.ObjectId, since, now time.Time) ([]bson.ObjectId, error) {
+	ids, err := enforce(d, inactive(category, since), bson.M{
+		"$set":   bson.M{"lock": true, "lock_reason": ArchivedReason, "archived_at": now},
+		"$unset": bson.M{"lock_until": ""},
+	})
+	if err != nil || len(ids) == 0 {
+		return ids, err
+	}
+	return ids, posts.SyncRanks(d, ids)
+}
+
+func inactive(category bson.ObjectId, since time.Time) bson.M {
+	return bson.M{
+		"category":    category,
+		"updated_at":  bson.M{"$lt": since},
+		"pinned":      bson.M{"$ne": true},
+		"deleted_at":  bson.M{"$exists": false},
+		"publish_at":  bson.M{"$exists": false},
+		"archived_at": bson.M{"$exists": false},
+		"$or": []bson.M{
+			{"reopened_at": bson.M{"$exists": false}},
+			{"reopened_at": bson.M{"$lt": since}},
+		},
+	}
+}
+
+func enforce(d deps, criteria, update bson.M) (ids []bson.ObjectId, err error) {
+	var due posts.Posts
+	err = d.Mgo().C("posts").Find(criteria).Select(bson.M{"_id": 1}).Limit(policyBatch).All(&due)
+	if err != nil || len(due) == 0 {
+		return
+	}
+	ids = due.IDs()
+	criteria["_id"] = bson.M{"$in": ids}
+	_, err = d.Mgo().C("posts").UpdateAll(criteria, update)
+	return
+}
@@ -0,0 +1,52 @@
+package trash
+
+import (
+	"errors"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var ItemNotFound = errors.New("Trash item has not been found by given criteria.")
+
+// FindList of trash items matching the filter. Next points to the last item
+// read when more could follow.
+func FindList(d deps, f Filter) (list Items, next *bson.ObjectId, err error) {
+	list = Items{}
+	criteria := bson.M{}
+	if len(f.Related) > 0 {
+		criteria["related"] = f.Related
+	}
+	if f.Category != nil {
+		criteria["category"] = *f.Category
+	}
+	if f.DeletedBy != nil {
+		criteria["deleted_by"] = *f.DeletedBy
+	}
+	if f.From != nil || f.To != nil {
+		deleted := bson.M{}
+		if f.From != nil {
+			deleted["$gte"] = *f.From
+		}
+		if f.To != nil {
+			deleted["$lt"] = *f.To
+		}
+		criteria["deleted_at"] = deleted
+	}
+	if f.Before != nil {
+		criteria["_id"] = bson.M{"$lt": *f.Before}
+	}
+	err = d.Mgo().C("trash").Find(criteria).Sort("-_id").Limit(f.Limit).All(&list)
+	if err == nil && len(list) == f.Limit && f.Limit > 0 {
+		next = &list[len(list)-1].ID
+	}
+	return
+}
+
+// FindOne trash item by its id.
+func FindOne(d deps, id bson.ObjectId) (item Item, err error) {
+	err = d.Mgo().C("trash").FindId(id).One(&item)
+	if err != nil {
+		err = ItemNotFound
+	}
+	return
+}
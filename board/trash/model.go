@@ -0,0 +1,76 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/tryanzu/core/board/comments"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/modules/helpers"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Item deleted by someone, restorable until purged. Comments deleted along
+// with their post are not listed, they are counted as cascaded instead.
+type Item struct {
+	ID        bson.ObjectId `bson:"_id,omitempty" json:"id"`
+	Related   string        `bson:"related" json:"related"`
+	RelatedID bson.ObjectId `bson:"related_id" json:"related_id"`
+	PostID    bson.ObjectId `bson:"post_id" json:"post_id"`
+	Category  bson.ObjectId `bson:"category" json:"category"`
+	UserID    bson.ObjectId `bson:"user_id" json:"user_id"`
+	DeletedBy bson.ObjectId `bson:"deleted_by" json:"deleted_by"`
+	Reason    string        `bson:"reason,omitempty" json:"reason,omitempty"`
+	Title     string        `bson:"title" json:"title"`
+	Excerpt   string        `bson:"excerpt" json:"excerpt"`
+	Cascaded  int           `bson:"cascaded,omitempty" json:"cascaded,omitempty"`
+	Deleted   time.Time     `bson:"deleted_at" json:"deleted_at"`
+}
+
+// Items list.
+type Items []Item
+
+// Filter of the trash list, latest deleted first.
+type Filter struct {
+	Related   string
+	Category  *bson.ObjectId
+	DeletedBy *bson.ObjectId
+	From      *time.Time
+	To        *time.Time
+	Before    *bson.ObjectId
+	Limit     int
+}
+
+const excerptLength = 140
+
+// FromPost deleted by given user, along with cascaded comments.
+func FromPost(p posts.Post, by bson.ObjectId, reason string, cascaded int) Item {
+	return Item{
+		Related:   "post",
+		RelatedID: p.Id,
+		PostID:    p.Id,
+		Category:  p.Category,
+		UserID:    p.UserId,
+		DeletedBy: by,
+		Reason:    reason,
+		Title:     p.Title,
+		Excerpt:   helpers.Truncate(p.Content, excerptLength),
+		Cascaded:  cascaded,
+		Deleted:   time.Now(),
+	}
+}
+
+// FromComment of given post deleted by given user.
+func FromComment(c comments.Comment, p posts.Post, by bson.ObjectId, reason string) Item {
+	return Item{
+		Related:   "comment",
+		RelatedID: c.Id,
+		PostID:    p.Id,
+		Category:  p.Category,
+		UserID:    c.UserId,
+		DeletedBy: by,
+		Reason:    reason,
+		Title:     p.Title,
+		Excerpt:   helpers.Truncate(c.Content, excerptLength),
+		Deleted:   time.Now(),
+	}
+}
@@ -0,0 +1,167 @@
+package trash
+
+import (
+	"errors"
+	"time"
+
+	posts "github.com/tryanzu/core/board/posts"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var InvalidRestore = errors.New("Invalid restore, the comment post is deleted. Restore the post first.")
+
+// Put item into the trash.
+func Put(d deps, item Item) error {
+	item.ID = bson.NewObjectId()
+	return d.Mgo().C("trash").Insert(&item)
+}
+
+// Restore trash item. Posts come back pinned as they were and with the
+// comments deleted along with them, comments get counted again on their post.
+func Restore(d deps, item Item) (err error) {
+	switch item.Related {
+	case "post":
+		err = d.Mgo().C("posts").UpdateId(item.RelatedID, bson.M{
+			"$unset":  bson.M{"deleted": "", "deleted_at": "", "deleted_by": ""},
+			"$rename": bson.M{"deleted_pinned": "pinned"},
+		})
+		if err != nil {
+			return
+		}
+		_, err = d.Mgo().C("comments").UpdateAll(bson.M{"deleted_with": item.RelatedID}, bson.M{
+			"$unset": bson.M{"deleted_at": "", "deleted_with": ""},
+		})
+	case "comment":
+		var post struct {
+			Deleted *time.Time `bson:"deleted_at"`
+		}
+		err = d.Mgo().C("posts").FindId(item.PostID).Select(bson.M{"deleted_at": 1}).One(&post)
+		if err != nil {
+			return
+		}
+		if post.Deleted != nil {
+			return InvalidRestore
+		}
+		var comment struct {
+			ReplyType string `bson:"reply_type"`
+		}
+		err = d.Mgo().C("comments").FindId(item.RelatedID).Select(bson.M{"reply_type": 1}).One(&comment)
+		if err != nil {
+			return
+		}
+		err = d.Mgo().C("comments").UpdateId(item.RelatedID, bson.M{
+			"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+		})
+		if err != nil {
+			return
+		}
+		if comment.ReplyType == "post" {
+			err = d.Mgo().C("posts").UpdateId(item.PostID, bson.M{"$inc": bson.M{"comments.count": 1}})
+		}
+	}
+	if err != nil {
+		return
+	}
+	return d.Mgo().C("trash").RemoveId(item.ID)
+}
+
+// Purge items deleted before given time for good. Purged posts take their
+// comments, revisions, reactions, bookmarks and rankings along, purged
+// comments take their replies.
+func Purge(d deps, before time.Time) (purged int, err error) {
+	var due Items
+	err = d.Mgo().C("trash").Find(bson.M{"deleted_at": bson.M{"$lt": before}}).All(&due)
+	if err != nil {
+		return
+	}
+	for _, item := range due {
+		switch item.Related {
+		case "post":
+			err = purgePost(d, item.RelatedID)
+		case "comment":
+			err = purgeComment(d, item.RelatedID)
+		}
+		if err != nil && err != mgo.ErrNotFound {
+			return
+		}
+		err = d.Mgo().C("trash").RemoveId(item.ID)
+		if err != nil {
+			return
+		}
+		purged++
+	}
+	return
+}
+
+func purgePost(d deps, id bson.ObjectId) error {
+	err := d.Mgo().C("posts").Remove(bson.M{"_id": id, "deleted_at": bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	list, err := commentIDs(d, bson.M{"$or": []bson.M{{"post_id": id}, {"reply_to": id}}})
+	if err != nil {
+		return err
+	}
+	err = purgeComments(d, list)
+	if err != nil {
+		return err
+	}
+	_, err = d.Mgo().C("post_revisions").RemoveAll(bson.M{"post_id": id})
+	if err != nil {
+		return err
+	}
+	err = purgeRelated(d, "post", []bson.ObjectId{id})
+	if err != nil {
+		return err
+	}
+	return posts.Unrank(d, []bson.ObjectId{id})
+}
+
+func purgeComment(d deps, id bson.ObjectId) error {
+	err := d.Mgo().C("comments").Remove(bson.M{"_id": id, "deleted_at": bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	replies, err := commentIDs(d, bson.M{"$or": []bson.M{{"ancestors": id}, {"reply_type": "comment", "reply_to": id}}})
+	if err != nil {
+		return err
+	}
+	return purgeComments(d, append(replies, id))
+}
+
+func commentIDs(d deps, criteria bson.M) (list []bson.ObjectId, err error) {
+	var found []struct {
+		ID bson.ObjectId `bson:"_id"`
+	}
+	err = d.Mgo().C("comments").Find(criteria).Select(bson.M{"_id": 1}).All(&found)
+	for _, c := range found {
+		list = append(list, c.ID)
+	}
+	return
+}
+
+func purgeComments(d deps, list []bson.ObjectId) error {
+	if len(list) == 0 {
+		return nil
+	}
+	_, err := d.Mgo().C("comments").RemoveAll(bson.M{"_id": bson.M{"$in": list}})
+	if err != nil {
+		return err
+	}
+	_, err = d.Mgo().C("comment_revisions").RemoveAll(bson.M{"comment_id": bson.M{"$in": list}})
+	if err != nil {
+		return err
+	}
+	return purgeRelated(d, "comment", list)
+}
+
+// purgeRelated reactions and bookmarks of purged items.
+func purgeRelated(d deps, related string, list []bson.ObjectId) error {
+	_, err := d.Mgo().C("votes").RemoveAll(bson.M{"type": related, "related_id": bson.M{"$in": list}})
+	if err != nil {
+		return err
+	}
+	_, err = d.Mgo().C("bookmarks").RemoveAll(bson.M{"related": related, "related_id": bson.M{"$in": list}})
+	return err
+}
@@ -20,3 +20,14 @@ func FindList(deps Deps, scopes ...common.Scope) (list List, err error) {
 	err = deps.Mgo().C("votes").Find(common.ByScope(scopes...)).All(&list)
 	return
 }
+
+// FindActiveList of votes over a votable item.
+func FindActiveList(deps Deps, votable Votable) (list List, err error) {
+	list = List{}
+	err = coll(deps).Find(bson.M{
+		"type":       votable.VotableType(),
+		"related_id": votable.VotableID(),
+		"deleted_at": bson.M{"$exists": false},
+	}).All(&list)
+	return
+}
@@ -1,6 +1,7 @@
 package votes
 
 import (
+	"github.com/tryanzu/core/core/config"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 
@@ -16,14 +17,26 @@ const (
 
 // Vote represents a reaction to a post || comment
 type Vote struct {
-	ID         bson.ObjectId `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID     bson.ObjectId `bson:"user_id" json:"user_id"`
-	Type       string        `bson:"type" json:"type"`
-	NestedType string        `bson:"nested_type,omitempty" json:"nested_type,omitempty"`
-	RelatedID  bson.ObjectId `bson:"related_id" json:"related_id"`
-	Value      string        `bson:"value" json:"value"`
-	Created    time.Time     `bson:"created_at" json:"created_at"`
-	Deleted    *time.Time    `bson:"deleted_at,omitempty" json:"-"`
+	ID          bson.ObjectId `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID      bson.ObjectId `bson:"user_id" json:"user_id"`
+	Type        string        `bson:"type" json:"type"`
+	NestedType  string        `bson:"nested_type,omitempty" json:"nested_type,omitempty"`
+	RelatedID   bson.ObjectId `bson:"related_id" json:"related_id"`
+	Value       string        `bson:"value" json:"value"`
+	OwnerID     bson.ObjectId `bson:"owner_id,omitempty" json:"-"`
+	PostID      bson.ObjectId `bson:"post_id,omitempty" json:"-"`
+	Category    bson.ObjectId `bson:"category,omitempty" json:"-"`
+	Awarded     Awarded       `bson:"awarded,omitempty" json:"-"`
+	Changes     int           `bson:"changes" json:"-"`
+	Created     time.Time     `bson:"created_at" json:"created_at"`
+	Deleted     *time.Time    `bson:"deleted_at,omitempty" json:"-"`
+	Neutralized *time.Time    `bson:"neutralized_at,omitempty" json:"-"`
+}
+
+// Awarded gains by a vote to the user giving it and the one receiving it.
+type Awarded struct {
+	Provider config.Gains `bson:"provider" json:"provider"`
+	Receiver config.Gains `bson:"receiver" json:"receiver"`
 }
 
 func (v Vote) Remove(deps Deps) error {
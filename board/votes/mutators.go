@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/tryanzu/core/core/config"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -27,7 +28,12 @@ func UpsertVote(deps Deps, item Votable, userID bson.ObjectId, kind string) (vot
 		err = errors.New("invalid vote type")
 		return
 	}
+	return ToggleVote(deps, item, userID, kind)
+}
 
+// ToggleVote creates or removes a vote with any value for given votable item<->user.
+// Callers are responsible of validating the value.
+func ToggleVote(deps Deps, item Votable, userID bson.ObjectId, kind string) (vote Vote, status voteStatus, err error) {
 	criteria := bson.M{
 		"type":       item.VotableType(),
 		"related_id": item.VotableID(),
@@ -35,6 +41,14 @@ func UpsertVote(deps Deps, item Votable, userID bson.ObjectId, kind string) (vot
 		"user_id":    userID,
 	}
 
+	// Votes neutralized by moderators can't be toggled back.
+	criteria["neutralized_at"] = bson.M{"$exists": true}
+	if n, _ := coll(deps).Find(criteria).Count(); n > 0 {
+		err = &NotAllowed{Reason: "vote has been neutralized"}
+		return
+	}
+	delete(criteria, "neutralized_at")
+
 	changes, err := coll(deps).Upsert(criteria, bson.M{
 		"$inc": bson.M{"changes": 1},
 		"$set": bson.M{
@@ -93,3 +107,81 @@ func UpsertVote(deps Deps, item Votable, userID bson.ObjectId, kind string) (vot
 	vote.Deleted = nil
 	return
 }
+
+// RetractOthers removes every active vote of the user over the item but the one with kept value.
+func RetractOthers(deps Deps, item Votable, userID bson.ObjectId, keep string) error {
+	_, err := coll(deps).UpdateAll(bson.M{
+		"type":       item.VotableType(),
+		"related_id": item.VotableID(),
+		"user_id":    userID,
+		"value":      bson.M{"$ne": keep},
+		"deleted_at": bson.M{"$exists": false},
+	}, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	return err
+}
+
+// Attribute a vote to the author of the reacted item, its post and category
+// so reactions can be aggregated without looking them up.
+func Attribute(deps Deps, vote Vote, owner, postID, category bson.ObjectId) (Vote, error) {
+	vote.OwnerID = owner
+	vote.PostID = postID
+	vote.Category = category
+	err := coll(deps).UpdateId(vote.ID, bson.M{"$set": bson.M{
+		"owner_id": owner,
+		"post_id":  postID,
+		"category": category,
+	}})
+	return vote, err
+}
+
+// Award gains along with a vote, so they can be taken back when the vote
+// is retracted or turns out to be part of a manipulation. Votes toggled
+// since the given copy was read are left alone, telling so with awarded.
+// Nothing gained is recorded as well, so votes lacking it are known to
+// predate awards.
+func Award(deps Deps, vote Vote, provider, receiver config.Gains) (awarded bool, err error) {
+	err = coll(deps).Update(bson.M{"_id": vote.ID, "changes": vote.Changes}, bson.M{"$inc": bson.M{
+		"awarded.provider.swords":  provider.Swords,
+		"awarded.provider.coins":   provider.Coins,
+		"awarded.provider.tribute": provider.Tribute,
+		"awarded.receiver.swords":  receiver.Swords,
+		"awarded.receiver.coins":   receiver.Coins,
+		"awarded.receiver.tribute": receiver.Tribute,
+	}})
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Reclaim what a vote awarded, clearing it in the same step so it can't be
+// taken back twice.
+func Reclaim(deps Deps, id bson.ObjectId) (awarded Awarded, err error) {
+	var vote Vote
+	_, err = coll(deps).Find(bson.M{"_id": id, "awarded": bson.M{"$exists": true}}).Apply(mgo.Change{
+		Update: bson.M{"$unset": bson.M{"awarded": ""}},
+	}, &vote)
+	if err == mgo.ErrNotFound {
+		return awarded, nil
+	}
+	return vote.Awarded, err
+}
+
+// Neutralize an active vote. It no longer counts nor can be toggled back,
+// the returned vote keeps the swords it had awarded.
+func Neutralize(deps Deps, id bson.ObjectId) (vote Vote, err error) {
+	now := time.Now()
+	_, err = coll(deps).Find(bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}).Apply(mgo.Change{
+		Update: bson.M{
+			"$set":   bson.M{"deleted_at": now, "neutralized_at": now},
+			"$inc":   bson.M{"changes": 1},
+			"$unset": bson.M{"awarded": ""},
+		},
+	}, &vote)
+	if err != nil {
+		return
+	}
+	vote.Deleted = &now
+	vote.Neutralized = &now
+	return
+}
@@ -0,0 +1,77 @@
+package votes
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var QuotaExceeded = errors.New("Daily reactions quota exceeded, try again tomorrow.")
+
+// Quota of reactions a user can give per day, every toggle counts.
+type Quota struct {
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	Unlimited bool      `json:"unlimited,omitempty"`
+	Resets    time.Time `json:"resets_at"`
+}
+
+// day daily quotas and caps are counted on, they all reset at UTC midnight.
+func day(now time.Time) string {
+	return now.UTC().Format("2006-01-02")
+}
+
+func quotaKey(userID bson.ObjectId, now time.Time) []byte {
+	return []byte("reactions:quota:" + userID.Hex() + ":" + day(now))
+}
+
+func makeQuota(limit, used int, now time.Time) Quota {
+	day := now.UTC().Truncate(24 * time.Hour)
+	q := Quota{Limit: limit, Used: used, Unlimited: limit <= 0, Resets: day.Add(24 * time.Hour)}
+	if !q.Unlimited && used < limit {
+		q.Remaining = limit - used
+	}
+	return q
+}
+
+// FindQuota of reactions left to a user today, under given limit.
+func FindQuota(deps Deps, userID bson.ObjectId, limit int, now time.Time) (Quota, error) {
+	v, err := deps.LedisDB().Get(quotaKey(userID, now))
+	if err != nil || v == nil {
+		return makeQuota(limit, 0, now), err
+	}
+	used, err := strconv.Atoi(string(v))
+	return makeQuota(limit, used, now), err
+}
+
+// SpendQuota of a user on a reaction. Nothing is spent once exceeded.
+func SpendQuota(deps Deps, userID bson.ObjectId, limit int, now time.Time) (Quota, error) {
+	db := deps.LedisDB()
+	key := quotaKey(userID, now)
+	used, err := db.Incr(key)
+	if err != nil {
+		return makeQuota(limit, 0, now), err
+	}
+	if used == 1 {
+		if _, err = db.Expire(key, 2*24*60*60); err != nil {
+			return makeQuota(limit, int(used), now), err
+		}
+	}
+	if limit > 0 && int(used) > limit {
+		used, err = db.Decr(key)
+		if err != nil {
+			return makeQuota(limit, int(used), now), err
+		}
+		return makeQuota(limit, int(used), now), QuotaExceeded
+	}
+	return makeQuota(limit, int(used), now), nil
+}
+
+// RefundQuota spent on a reaction that was refused after all.
+func RefundQuota(deps Deps, userID bson.ObjectId, now time.Time) error {
+	_, err := deps.LedisDB().Decr(quotaKey(userID, now))
+	return err
+}
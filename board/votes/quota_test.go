@@ -0,0 +1,19 @@
+package votes
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestQuota(t *testing.T) {
+	Convey("Quotas reset at the start of the next day", t, func() {
+		now := time.Date(2018, 3, 10, 18, 30, 0, 0, time.UTC)
+		q := makeQuota(20, 5, now)
+		So(q.Remaining, ShouldEqual, 15)
+		So(q.Resets, ShouldEqual, time.Date(2018, 3, 11, 0, 0, 0, 0, time.UTC))
+		So(makeQuota(20, 25, now).Remaining, ShouldEqual, 0)
+		So(makeQuota(0, 25, now).Unlimited, ShouldBeTrue)
+	})
+}
@@ -0,0 +1,50 @@
+package votes
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tryanzu/core/core/config"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Earn gains from a reaction within its daily cap. Capped gains are
+// recorded as earned by the user today.
+func Earn(deps Deps, userID bson.ObjectId, reaction string, gains, cap config.Gains, now time.Time) (config.Gains, error) {
+	if cap.Zero() {
+		return gains, nil
+	}
+	db := deps.LedisDB()
+	key := []byte("reactions:earned:" + userID.Hex() + ":" + reaction + ":" + day(now))
+	earned := func(field string) (int, error) {
+		v, err := db.HGet(key, []byte(field))
+		if err != nil || v == nil {
+			return 0, err
+		}
+		return strconv.Atoi(string(v))
+	}
+	var (
+		today config.Gains
+		err   error
+	)
+	if today.Swords, err = earned("swords"); err != nil {
+		return gains, err
+	}
+	if today.Coins, err = earned("coins"); err != nil {
+		return gains, err
+	}
+	if today.Tribute, err = earned("tribute"); err != nil {
+		return gains, err
+	}
+	gains = gains.Capped(today, cap)
+	for field, n := range map[string]int{"swords": gains.Swords, "coins": gains.Coins, "tribute": gains.Tribute} {
+		if n <= 0 {
+			continue
+		}
+		if _, err = db.HIncrBy(key, []byte(field), int64(n)); err != nil {
+			return gains, err
+		}
+	}
+	_, err = db.HExpire(key, 2*24*60*60)
+	return gains, err
+}
@@ -0,0 +1,322 @@
+package votes
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var InvalidPeriod = errors.New("Invalid period, use one of day, week, month, year or all.")
+
+// Periods reactions can be aggregated over, in days. Zero means all time.
+var Periods = map[string]int{
+	"day":   1,
+	"week":  7,
+	"month": 30,
+	"year":  365,
+	"all":   0,
+}
+
+// Cached stats live this long at most, in seconds.
+const statsTTL = 600
+
+// Point of a reactions timeline, a day with its reactions by value.
+type Point struct {
+	Date      string `json:"date"`
+	Reactions Votes  `json:"reactions"`
+}
+
+// Timeline of reactions with their totals by value.
+type Timeline struct {
+	Total Votes   `json:"total"`
+	Days  []Point `json:"days"`
+}
+
+// Reacted post or comment among the most reacted ones.
+type Reacted struct {
+	ID        bson.ObjectId `json:"id"`
+	Type      string        `json:"type"`
+	PostID    bson.ObjectId `json:"post_id"`
+	Count     int           `json:"count"`
+	Reactions Votes         `json:"reactions"`
+}
+
+// Mix of reactions over a post and its comments.
+type Mix struct {
+	Post     Votes `json:"post"`
+	Comments Votes `json:"comments"`
+}
+
+type statRow struct {
+	Key struct {
+		Date  string `bson:"date"`
+		Type  string `bson:"type"`
+		Value string `bson:"value"`
+	} `bson:"_id"`
+	Count int `bson:"count"`
+}
+
+type topRow struct {
+	ID     bson.ObjectId `bson:"_id"`
+	PostID bson.ObjectId `bson:"post_id"`
+	Count  int           `bson:"count"`
+	Values []struct {
+		Value string `bson:"value"`
+		Count int    `bson:"count"`
+	} `bson:"values"`
+}
+
+// Since when a period starts, zero for all time.
+func Since(period string, now time.Time) (since time.Time, err error) {
+	days, exists := Periods[period]
+	if !exists {
+		return since, InvalidPeriod
+	}
+	if days > 0 {
+		since = now.AddDate(0, 0, -days)
+	}
+	return
+}
+
+// FindTimeline of reactions a user received over given period, or the ones
+// given by the user.
+func FindTimeline(deps Deps, userID bson.ObjectId, given bool, period string) (t Timeline, err error) {
+	since, err := Since(period, time.Now())
+	if err != nil {
+		return
+	}
+	field, direction := "owner_id", "received"
+	if given {
+		field, direction = "user_id", "given"
+	}
+	key := "timeline:" + direction + ":" + userID.Hex() + ":" + period
+	err = cached(deps, key, []string{"user:" + userID.Hex()}, &t, func() error {
+		var rows []statRow
+		err := coll(deps).Pipe([]bson.M{
+			{"$match": active(bson.M{field: userID, "type": bson.M{"$in": []string{"post", "comment"}}}, since)},
+			{"$group": bson.M{
+				"_id": bson.M{
+					"date":  bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$created_at"}},
+					"value": "$value",
+				},
+				"count": bson.M{"$sum": 1},
+			}},
+		}).All(&rows)
+		if err != nil {
+			return err
+		}
+		t = timeline(rows)
+		return nil
+	})
+	return
+}
+
+// FindTopReacted posts or comments over given period, optionally within
+// a category.
+func FindTopReacted(deps Deps, kind string, category *bson.ObjectId, period string, limit int) (list []Reacted, err error) {
+	since, err := Since(period, time.Now())
+	if err != nil {
+		return
+	}
+	criteria := bson.M{"type": kind}
+	tag, scope := "top", "all"
+	if category != nil {
+		criteria["category"] = *category
+		tag, scope = "category:"+category.Hex(), category.Hex()
+	}
+	key := "top:" + kind + ":" + scope + ":" + period + ":" + strconv.Itoa(limit)
+	err = cached(deps, key, []string{tag}, &list, func() error {
+		var rows []topRow
+		err := coll(deps).Pipe([]bson.M{
+			{"$match": active(criteria, since)},
+			{"$group": bson.M{
+				"_id":     bson.M{"id": "$related_id", "value": "$value"},
+				"post_id": bson.M{"$first": "$post_id"},
+				"count":   bson.M{"$sum": 1},
+			}},
+			{"$group": bson.M{
+				"_id":     "$_id.id",
+				"post_id": bson.M{"$first": "$post_id"},
+				"count":   bson.M{"$sum": "$count"},
+				"values":  bson.M{"$push": bson.M{"value": "$_id.value", "count": "$count"}},
+			}},
+			{"$sort": bson.D{{Name: "count", Value: -1}, {Name: "_id", Value: -1}}},
+			// Read ahead, deleted and held items are left out below.
+			{"$limit": limit * 3},
+		}).All(&rows)
+		if err != nil {
+			return err
+		}
+		rows, err = visible(deps, kind, rows)
+		if err != nil {
+			return err
+		}
+		if len(rows) > limit {
+			rows = rows[:limit]
+		}
+		list = topReacted(kind, rows)
+		return nil
+	})
+	return
+}
+
+// FindMix of reactions over a post and its comments.
+func FindMix(deps Deps, postID bson.ObjectId) (mix Mix, err error) {
+	key := "mix:" + postID.Hex()
+	err = cached(deps, key, []string{"post:" + postID.Hex()}, &mix, func() error {
+		var rows []statRow
+		err := coll(deps).Pipe([]bson.M{
+			// Post votes stored before attribution have no post_id yet.
+			{"$match": active(bson.M{"$or": []bson.M{
+				{"post_id": postID},
+				{"type": "post", "related_id": postID},
+			}}, time.Time{})},
+			{"$group": bson.M{
+				"_id":   bson.M{"type": "$type", "value": "$value"},
+				"count": bson.M{"$sum": 1},
+			}},
+		}).All(&rows)
+		if err != nil {
+			return err
+		}
+		mix = Mix{Post: Votes{}, Comments: Votes{}}
+		for _, r := range rows {
+			if r.Key.Type == "post" {
+				mix.Post[r.Key.Value] += r.Count
+			} else {
+				mix.Comments[r.Key.Value] += r.Count
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// InvalidateStats cached for everything a vote takes part in.
+func InvalidateStats(deps Deps, vote Vote) error {
+	tags := []string{"top", "user:" + vote.UserID.Hex()}
+	if vote.OwnerID.Valid() {
+		tags = append(tags, "user:"+vote.OwnerID.Hex())
+	}
+	if vote.PostID.Valid() {
+		tags = append(tags, "post:"+vote.PostID.Hex())
+	}
+	if vote.Category.Valid() {
+		tags = append(tags, "category:"+vote.Category.Hex())
+	}
+	for _, tag := range tags {
+		if _, err := deps.LedisDB().Incr([]byte("votes:stats:v:" + tag)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cached stats under given key. Keys carry the version of their tags so
+// bumping a tag version leaves every stat it tags behind.
+func cached(deps Deps, key string, tags []string, v interface{}, fetch func() error) error {
+	db := deps.LedisDB()
+	versions := make([]string, len(tags))
+	for i, tag := range tags {
+		n, err := db.Get([]byte("votes:stats:v:" + tag))
+		if err != nil {
+			return err
+		}
+		versions[i] = "0"
+		if n != nil {
+			versions[i] = string(n)
+		}
+	}
+	k := []byte("votes:stats:" + key + ":" + strings.Join(versions, "."))
+	cache, err := db.Get(k)
+	if err != nil {
+		return err
+	}
+	if cache != nil && json.Unmarshal(cache, v) == nil {
+		return nil
+	}
+	err = fetch()
+	if err != nil {
+		return err
+	}
+	cache, err = json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return db.SetEX(k, statsTTL, cache)
+}
+
+func active(criteria bson.M, since time.Time) bson.M {
+	criteria["deleted_at"] = bson.M{"$exists": false}
+	if since.IsZero() == false {
+		criteria["created_at"] = bson.M{"$gte": since}
+	}
+	return criteria
+}
+
+// visible rows of posts or comments neither deleted nor held for review.
+func visible(deps Deps, kind string, rows []topRow) ([]topRow, error) {
+	ids := make([]bson.ObjectId, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+	}
+	var live []struct {
+		ID bson.ObjectId `bson:"_id"`
+	}
+	err := deps.Mgo().C(kind + "s").Find(bson.M{
+		"_id":        bson.M{"$in": ids},
+		"deleted_at": bson.M{"$exists": false},
+	}).Select(bson.M{"_id": 1}).All(&live)
+	if err != nil {
+		return rows, err
+	}
+	alive := make(map[bson.ObjectId]bool, len(live))
+	for _, item := range live {
+		alive[item.ID] = true
+	}
+	list := []topRow{}
+	for _, r := range rows {
+		if alive[r.ID] {
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+func timeline(rows []statRow) Timeline {
+	t := Timeline{Total: Votes{}, Days: []Point{}}
+	days := map[string]Votes{}
+	for _, r := range rows {
+		if _, exists := days[r.Key.Date]; !exists {
+			days[r.Key.Date] = Votes{}
+			t.Days = append(t.Days, Point{Date: r.Key.Date, Reactions: days[r.Key.Date]})
+		}
+		days[r.Key.Date][r.Key.Value] += r.Count
+		t.Total[r.Key.Value] += r.Count
+	}
+	sort.Slice(t.Days, func(i, j int) bool {
+		return t.Days[i].Date < t.Days[j].Date
+	})
+	return t
+}
+
+func topReacted(kind string, rows []topRow) []Reacted {
+	list := make([]Reacted, len(rows))
+	for i, r := range rows {
+		// Posts are their own post, votes attributed later may lack it.
+		postID := r.PostID
+		if kind == "post" {
+			postID = r.ID
+		}
+		list[i] = Reacted{ID: r.ID, Type: kind, PostID: postID, Count: r.Count, Reactions: Votes{}}
+		for _, v := range r.Values {
+			list[i].Reactions[v.Value] += v.Count
+		}
+	}
+	return list
+}
@@ -0,0 +1,37 @@
+package votes
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStats(t *testing.T) {
+	Convey("Periods start back from now, all time has no start", t, func() {
+		now := time.Date(2018, 3, 10, 12, 0, 0, 0, time.UTC)
+		since, err := Since("week", now)
+		So(err, ShouldBeNil)
+		So(since, ShouldEqual, time.Date(2018, 3, 3, 12, 0, 0, 0, time.UTC))
+		since, err = Since("all", now)
+		So(since.IsZero(), ShouldBeTrue)
+		_, err = Since("decade", now)
+		So(err, ShouldEqual, InvalidPeriod)
+	})
+
+	Convey("Timeline days are sorted and summed up by value", t, func() {
+		row := func(date, value string, n int) (r statRow) {
+			r.Key.Date, r.Key.Value, r.Count = date, value, n
+			return
+		}
+		tl := timeline([]statRow{
+			row("2018-03-02", "useful", 2),
+			row("2018-03-01", "useful", 1),
+			row("2018-03-02", "wordy", 1),
+		})
+		So(len(tl.Days), ShouldEqual, 2)
+		So(tl.Days[0].Date, ShouldEqual, "2018-03-01")
+		So(tl.Days[1].Reactions, ShouldResemble, Votes{"useful": 2, "wordy": 1})
+		So(tl.Total, ShouldResemble, Votes{"useful": 3, "wordy": 1})
+	})
+}
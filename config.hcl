@@ -26,9 +26,65 @@ banReason spoofing {
 }
 banReason other {}
 
+// Reactions section.
+// weight: how much a reaction counts when sorting comments, negative ones count against.
+// exec: script exporting the provider and receiver gains ({swords, coins, tribute}, negative
+// ones are taken away) and a daily cap per user, days reset at UTC midnight. `vote` holds the
+// reaction type and value.
+reaction useful {
+    weight = 2
+    exec = <<JS
+        exports.receiver = {swords: vote.type == 'comment' ? 8 : 4};
+    JS
+}
+reaction concise {
+    weight = 1
+    exec = <<JS
+        exports.receiver = {swords: vote.type == 'comment' ? 8 : 4};
+    JS
+}
+reaction offtopic {
+    weight = -1
+    exec = <<JS
+        exports.receiver = {swords: vote.type == 'comment' ? -2 : -1};
+    JS
+}
+reaction wordy {
+    weight = -0.5
+    exec = <<JS
+        exports.receiver = {swords: vote.type == 'comment' ? -2 : -1};
+    JS
+}
+
+// Reaction sets named in a category reactSet override the effects above.
+// reactSet questions {
+//     reaction useful {
+//         exec = <<JS
+//             exports.receiver = {swords: 10, coins: 1};
+//             exports.cap = {swords: 50, coins: 5};
+//         JS
+//     }
+// }
+
 // Flag reasons section.
 flag spam {}
 flag rude {}
 flag duplicate {}
 flag needs_review {}
-flag other {}
\ No newline at end of file
+flag other {}
+
+// Content filters section.
+// match: word | regex | domain
+// action: replace | block | review | flag
+// Chat messages can't be held, review filters block them there.
+filter profanity {
+    match = "word"
+    patterns = []
+    action = "replace"
+}
+filter spam_links {
+    match = "domain"
+    patterns = []
+    action = "flag"
+    reason = "spam"
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Filter actions.
+const (
+	FilterReplace = "replace"
+	FilterBlock   = "block"
+	FilterReview  = "review"
+	FilterFlag    = "flag"
+)
+
+// Filter config def. Matches content by exact words, regular expressions
+// or link domains and applies an action over it.
+type Filter struct {
+	Match    string   `hcl:"match"`
+	Patterns []string `hcl:"patterns"`
+	Action   string   `hcl:"action"`
+	Reason   string   `hcl:"reason"`
+}
+
+// Compile filter patterns into a single regular expression.
+func (f Filter) Compile() (*regexp.Regexp, error) {
+	alts := make([]string, 0, len(f.Patterns))
+	for _, p := range f.Patterns {
+		if len(strings.TrimSpace(p)) == 0 {
+			continue
+		}
+		if f.Match == "regex" {
+			alts = append(alts, "(?:"+p+")")
+			continue
+		}
+		alts = append(alts, regexp.QuoteMeta(p))
+	}
+	if len(alts) == 0 {
+		return nil, nil
+	}
+	expr := strings.Join(alts, "|")
+	switch f.Match {
+	case "regex":
+		expr = `(?i)(?:` + expr + `)`
+	case "domain":
+		// Listed domains including any of their subdomains.
+		expr = `(?i)\b(?:[a-z0-9\-]+\.)*(?:` + expr + `)\b`
+	default:
+		expr = `(?i)\b(?:` + expr + `)\b`
+	}
+	return regexp.Compile(expr)
+}
+
+// FlagReason to use when a filter flags or holds content.
+func (f Filter) FlagReason() string {
+	if len(f.Reason) > 0 {
+		return f.Reason
+	}
+	return "needs_review"
+}
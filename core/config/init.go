@@ -94,7 +94,7 @@ func (c *Config) UserCopy() (conf map[string]interface{}) {
 func (c *Config) Boot() {
 	c.current = nil
 	c.Merge("./static/resources/config.toml", false)
-	c.Merge("./config.toml", true)
+	c.Merge("./config.toml", false)
 	if level, err := logging.LogLevel(strings.ToUpper(c.current.Runtime.LoggingLevel)); err == nil {
 		LoggingBackend.SetLevel(level, "")
 		log.Noticef("logging level reloaded	level=%s", c.current.Runtime.LoggingLevel)
@@ -112,6 +112,10 @@ func (c *Config) Boot() {
 
 	c.rules = &rules
 	log.Notice("config loaded from filesystem")
+
+	// Reload signal once both config sources (toml & hcl) are in place.
+	close(c.Reload)
+	c.Reload = make(chan struct{})
 }
 
 func (c *Config) Merge(file string, reload bool) {
@@ -36,6 +36,20 @@ type anzuSecurity struct {
 
 type anzuRuntime struct {
 	LoggingLevel string `json:"logLevel"`
+
+	// TrashRetention in days before deleted posts and comments are purged.
+	TrashRetention int `json:"trashRetentionDays"`
+
+	// NeutralizeVotes reported as manipulated right away, without waiting for a moderator.
+	NeutralizeVotes bool `json:"neutralizeManipulatedVotes"`
+}
+
+// TrashRetentionDays with a default of 30 days when not set.
+func (r anzuRuntime) TrashRetentionDays() int {
+	if r.TrashRetention <= 0 {
+		return 30
+	}
+	return r.TrashRetention
 }
 
 type Flag struct {
@@ -45,8 +59,10 @@ type Flag struct {
 
 type Rules struct {
 	Reactions  map[string]*ReactionEffect `hcl:"reaction"`
+	ReactSets  map[string]*ReactSet       `hcl:"reactSet"`
 	BanReasons map[string]*BanReason      `hcl:"banReason"`
 	Flags      map[string]*Flag           `hcl:"flag"`
+	Filters    map[string]*Filter         `hcl:"filter"`
 }
 
 type anzuSite struct {
@@ -62,6 +78,10 @@ type anzuSite struct {
 	Quickstart     siteQuickstart `json:"quickstart"`
 	Reactions      [][]string     `json:"reactions"`
 	ThirdPartyAuth []string       `json:"thirdPartyAuth"`
+
+	// PublicEditHistory lets everyone see posts and comments edit history, not
+	// only their authors and moderators.
+	PublicEditHistory bool `json:"publicEditHistory"`
 }
 
 func (site anzuSite) MakeURL(url string) string {
@@ -6,24 +6,126 @@ import (
 
 type ReactionEffect struct {
 	Code string `hcl:"exec"`
+
+	// Weight of the reaction when scoring comments, negative ones count against.
+	Weight *float64 `hcl:"weight"`
+}
+
+// ReactSet overrides the effects of reactions in categories using the set.
+type ReactSet struct {
+	Reactions map[string]*ReactionEffect `hcl:"reaction"`
+}
+
+// Gains of a user on a reaction, negative ones are taken away.
+type Gains struct {
+	Swords  int `bson:"swords,omitempty" json:"swords"`
+	Coins   int `bson:"coins,omitempty" json:"coins"`
+	Tribute int `bson:"tribute,omitempty" json:"tribute"`
+}
+
+// Neg gains, to take them back.
+func (g Gains) Neg() Gains {
+	return Gains{-g.Swords, -g.Coins, -g.Tribute}
+}
+
+// Add other gains.
+func (g Gains) Add(o Gains) Gains {
+	return Gains{g.Swords + o.Swords, g.Coins + o.Coins, g.Tribute + o.Tribute}
+}
+
+// Zero tells whether there is nothing to gain.
+func (g Gains) Zero() bool {
+	return g == Gains{}
 }
 
-type Rewards struct {
-	Provider int64
-	Receiver int64
+// Capped gains by what was already earned under a cap. Zero caps and
+// negative gains are not capped.
+func (g Gains) Capped(earned, cap Gains) Gains {
+	capped := func(n, earned, cap int) int {
+		if cap <= 0 || n <= 0 {
+			return n
+		}
+		if left := cap - earned; left < n {
+			if left < 0 {
+				return 0
+			}
+			return left
+		}
+		return n
+	}
+	return Gains{
+		Swords:  capped(g.Swords, earned.Swords, cap.Swords),
+		Coins:   capped(g.Coins, earned.Coins, cap.Coins),
+		Tribute: capped(g.Tribute, earned.Tribute, cap.Tribute),
+	}
+}
+
+// Effect of a reaction on the user giving it and the one receiving it.
+type Effect struct {
+	Provider Gains
+	Receiver Gains
+
+	// Cap of what each user can gain from the reaction per day.
+	Cap Gains
 }
 
-func (re ReactionEffect) Rewards() (Rewards, error) {
+// Effect of the reaction over a post or comment. The script gets the vote
+// type and value and exports provider, receiver and cap objects with
+// swords, coins and tribute. Plain numbers are taken as swords.
+func (re ReactionEffect) Effect(related, value string) (Effect, error) {
 	vm := goja.New()
+	vm.Set("vote", map[string]interface{}{"type": related, "value": value})
 	vm.RunString(`
 		var exports = {};
 	`)
 	if _, err := vm.RunString(re.Code); err != nil {
-		return Rewards{}, err
+		return Effect{}, err
 	}
 	obj := vm.Get("exports").ToObject(vm)
-	provider := obj.Get("provider").ToInteger()
-	receiver := obj.Get("receiver").ToInteger()
+	gains := func(name string) (g Gains) {
+		v := obj.Get(name)
+		if v == nil || goja.IsUndefined(v) || goja.IsNull(v) {
+			return
+		}
+		if o, ok := v.Export().(map[string]interface{}); ok {
+			field := func(key string) int {
+				if n, exists := o[key]; exists {
+					return int(vm.ToValue(n).ToInteger())
+				}
+				return 0
+			}
+			return Gains{field("swords"), field("coins"), field("tribute")}
+		}
+		return Gains{Swords: int(v.ToInteger())}
+	}
+	return Effect{
+		Provider: gains("provider"),
+		Receiver: gains("receiver"),
+		Cap:      gains("cap"),
+	}, nil
+}
 
-	return Rewards{provider, receiver}, nil
+// Reaction effect in a category using given reaction sets. The first set
+// overriding the reaction wins over the default one.
+func (r Rules) Reaction(name string, sets []string) (*ReactionEffect, bool) {
+	for _, s := range sets {
+		if set, exists := r.ReactSets[s]; exists && set != nil {
+			if re, exists := set.Reactions[name]; exists && re != nil {
+				return re, true
+			}
+		}
+	}
+	re, exists := r.Reactions[name]
+	return re, exists && re != nil
+}
+
+// ReactionWeights of reactions having one configured.
+func (r Rules) ReactionWeights() map[string]float64 {
+	m := map[string]float64{}
+	for name, re := range r.Reactions {
+		if re != nil && re.Weight != nil {
+			m[name] = *re.Weight
+		}
+	}
+	return m
 }
@@ -0,0 +1,55 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/tryanzu/core/core/config"
+)
+
+const reactionRules = `
+reaction useful {
+    exec = "exports.receiver = {swords: vote.type == 'comment' ? 8 : 4, coins: 1}; exports.cap = {swords: 40};"
+}
+reaction wordy {
+    exec = "exports.provider = 1; exports.receiver = {swords: -1};"
+}
+reactSet questions {
+    reaction useful {
+        exec = "exports.receiver = {swords: 10, tribute: 2};"
+    }
+}
+`
+
+func TestReactionEffects(t *testing.T) {
+	Convey("Reaction scripts export gains for each party", t, func() {
+		var rules config.Rules
+		So(hcl.Unmarshal([]byte(reactionRules), &rules), ShouldBeNil)
+
+		re, exists := rules.Reaction("useful", nil)
+		So(exists, ShouldBeTrue)
+		effect, err := re.Effect("comment", "useful")
+		So(err, ShouldBeNil)
+		So(effect.Receiver, ShouldResemble, config.Gains{Swords: 8, Coins: 1})
+		So(effect.Provider.Zero(), ShouldBeTrue)
+		So(effect.Cap, ShouldResemble, config.Gains{Swords: 40})
+
+		re, _ = rules.Reaction("wordy", []string{"questions"})
+		effect, _ = re.Effect("post", "wordy")
+		So(effect.Provider, ShouldResemble, config.Gains{Swords: 1})
+		So(effect.Receiver, ShouldResemble, config.Gains{Swords: -1})
+
+		Convey("Reaction sets of a category override them", func() {
+			re, _ := rules.Reaction("useful", []string{"default", "questions"})
+			effect, _ := re.Effect("post", "useful")
+			So(effect.Receiver, ShouldResemble, config.Gains{Swords: 10, Tribute: 2})
+		})
+
+		Convey("Positive gains are capped by what was earned today", func() {
+			g := config.Gains{Swords: 8, Coins: 1, Tribute: -2}
+			So(g.Capped(config.Gains{Swords: 36, Coins: 9}, config.Gains{Swords: 40}), ShouldResemble, config.Gains{Swords: 4, Coins: 1, Tribute: -2})
+			So(g.Capped(config.Gains{Swords: 50}, config.Gains{Swords: 40}).Swords, ShouldEqual, 0)
+		})
+	})
+}
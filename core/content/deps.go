@@ -15,10 +15,12 @@ type deps interface {
 
 func Boot() {
 	log.SetBackend(config.LoggingBackend)
+	compileFilters(config.C.Rules())
 	go func() {
 		for {
 			<-config.C.Reload
 			log.SetBackend(config.LoggingBackend)
+			compileFilters(config.C.Rules())
 		}
 	}()
 }
@@ -0,0 +1,91 @@
+package content
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tryanzu/core/board/emojis"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var emojiCode, _ = regexp.Compile(`:([a-z0-9_\-]{2,32}):`)
+
+// ExpandEmojis runs only the emoji shortcode expansion over a parseable.
+func ExpandEmojis(d deps, c Parseable) (Parseable, error) {
+	return preReplaceEmojiTags(d, c)
+}
+
+// Replace known :shortcode: with stable emoji tags so renaming emojis keeps content intact.
+func preReplaceEmojiTags(d deps, c Parseable) (processed Parseable, err error) {
+	processed = c
+	content := processed.GetContent()
+	if emojiCode.MatchString(content) == false {
+		return
+	}
+	list, err := emojis.FindList(d)
+	if err != nil || len(list) == 0 {
+		return processed, nil
+	}
+	codes := list.ByShortcode()
+	category := parseableCategory(d, processed)
+	content = emojiCode.ReplaceAllStringFunc(content, func(match string) string {
+		e, exists := codes[match[1:len(match)-1]]
+		if exists == false || e.AllowedIn(category) == false {
+			return match
+		}
+		return "[emoji:" + e.ID.Hex() + "]"
+	})
+	processed = processed.UpdateContent(content)
+	return
+}
+
+// Replace emoji tags with their images, deleted emojis fall back to shortcodes.
+func postReplaceEmojiTags(d deps, c Parseable, list tags) (processed Parseable, err error) {
+	processed = c
+	tagged := list.withTag("emoji")
+	if len(tagged) == 0 {
+		return
+	}
+	all, err := emojis.FindAll(d)
+	if err != nil {
+		return
+	}
+	byID := all.ByID()
+	content := processed.GetContent()
+	for _, tag := range tagged {
+		if id := tag.Params[0]; bson.IsObjectIdHex(id) {
+			e, exists := byID[bson.ObjectIdHex(id)]
+			if exists == false {
+				continue
+			}
+			code := ":" + e.Shortcode + ":"
+			replacement := code
+			if e.Deleted == nil {
+				replacement = `![` + code + `](` + e.URL + `)`
+			}
+			content = strings.Replace(content, tag.Original, replacement, -1)
+		}
+	}
+	processed = processed.UpdateContent(content)
+	return
+}
+
+// parseableCategory finds where the content lives, so category restricted emojis can be checked.
+func parseableCategory(d deps, c Parseable) (category bson.ObjectId) {
+	meta := c.GetParseableMeta()
+	if id, ok := meta["category"].(bson.ObjectId); ok && id.Valid() {
+		return id
+	}
+	postID, ok := meta["post_id"].(bson.ObjectId)
+	if ok == false || postID.Valid() == false {
+		return
+	}
+	var post struct {
+		Category bson.ObjectId `bson:"category"`
+	}
+	err := d.Mgo().C("posts").FindId(postID).Select(bson.M{"category": 1}).One(&post)
+	if err != nil {
+		return
+	}
+	return post.Category
+}
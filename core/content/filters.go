@@ -0,0 +1,190 @@
+package content
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tryanzu/core/board/flags"
+	"github.com/tryanzu/core/core/config"
+	"github.com/tryanzu/core/core/events"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	filtersMu sync.RWMutex
+	filters   []filter
+)
+
+// Blocked content error, returned when a filter rule rejects the content.
+type Blocked struct {
+	Rule string
+}
+
+func (e Blocked) Error() string {
+	return "content not allowed by site rules"
+}
+
+// Reviewable parseables can be held back for moderator review by filters.
+type Reviewable interface {
+	Parseable
+	HoldForReview() Parseable
+}
+
+// Text is a plain parseable used to run filters over short fields (titles, usernames).
+type Text struct {
+	ID       bson.ObjectId
+	UserID   bson.ObjectId
+	Category bson.ObjectId
+	Related  string
+	Content  string
+	Held     bool
+}
+
+func (t Text) GetContent() string {
+	return t.Content
+}
+
+func (t Text) UpdateContent(content string) Parseable {
+	t.Content = content
+	return t
+}
+
+func (t Text) GetParseableMeta() map[string]interface{} {
+	meta := make(map[string]interface{})
+	meta["id"] = t.ID
+	meta["related"] = t.Related
+	meta["user_id"] = t.UserID
+	if t.Category.Valid() {
+		meta["category"] = t.Category
+	}
+	return meta
+}
+
+func (t Text) HoldForReview() Parseable {
+	t.Held = true
+	return t
+}
+
+type filter struct {
+	name string
+	rule config.Filter
+	re   *regexp.Regexp
+}
+
+type verdict struct {
+	content string
+	blocked string
+	review  []filter
+	flag    []filter
+}
+
+// Filter runs only the configured content filters over a parseable.
+func Filter(d deps, c Parseable) (Parseable, error) {
+	return preApplyFilters(d, c)
+}
+
+func preApplyFilters(d deps, c Parseable) (processed Parseable, err error) {
+	processed = c
+	filtersMu.RLock()
+	list := filters
+	filtersMu.RUnlock()
+	if len(list) == 0 {
+		return
+	}
+	v := applyFilters(list, processed.GetContent())
+	if len(v.blocked) > 0 {
+		err = Blocked{Rule: v.blocked}
+		return
+	}
+	processed = processed.UpdateContent(v.content)
+	if len(v.review) > 0 {
+		held, ok := processed.(Reviewable)
+		if !ok {
+			err = Blocked{Rule: v.review[0].name}
+			return
+		}
+		processed = held.HoldForReview()
+	}
+	for _, f := range append(v.review, v.flag...) {
+		err = flagFiltered(d, processed, f)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// applyFilters over content in order, replacing matches along the way.
+func applyFilters(list []filter, content string) (v verdict) {
+	v.content = content
+	for _, f := range list {
+		if f.re.MatchString(v.content) == false {
+			continue
+		}
+		switch f.rule.Action {
+		case config.FilterBlock:
+			v.blocked = f.name
+			return
+		case config.FilterReview:
+			v.review = append(v.review, f)
+		case config.FilterFlag:
+			v.flag = append(v.flag, f)
+		default:
+			v.content = f.re.ReplaceAllStringFunc(v.content, func(match string) string {
+				return strings.Repeat("*", len([]rune(match)))
+			})
+		}
+	}
+	return
+}
+
+func flagFiltered(d deps, c Parseable, f filter) error {
+	meta := c.GetParseableMeta()
+	id, _ := meta["id"].(bson.ObjectId)
+	userID, _ := meta["user_id"].(bson.ObjectId)
+	related, _ := meta["related"].(string)
+	flag := flags.Flag{
+		UserID:    userID,
+		RelatedTo: related,
+		Content:   "System has sent this flag. Filter: " + f.name,
+		Reason:    f.rule.FlagReason(),
+		System:    true,
+	}
+	if id.Valid() {
+		flag.RelatedID = &id
+	}
+	flag, err := flags.UpsertFlag(d, flag)
+	if err != nil {
+		return err
+	}
+	events.In <- events.NewFlag(flag.ID)
+	return nil
+}
+
+// compileFilters from config rules, sorted by name so they run in a stable order.
+func compileFilters(rules config.Rules) {
+	names := make([]string, 0, len(rules.Filters))
+	for name := range rules.Filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	list := make([]filter, 0, len(names))
+	for _, name := range names {
+		rule := rules.Filters[name]
+		re, err := rule.Compile()
+		if err != nil {
+			log.Errorf("invalid content filter, skipping	name=%s err=%v", name, err)
+			continue
+		}
+		if re == nil {
+			continue
+		}
+		list = append(list, filter{name, *rule, re})
+	}
+	filtersMu.Lock()
+	filters = list
+	filtersMu.Unlock()
+	log.Infof("content filters loaded	count=%v", len(list))
+}
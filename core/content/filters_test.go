@@ -0,0 +1,50 @@
+package content
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/tryanzu/core/core/config"
+)
+
+func compiled(name string, rule config.Filter) filter {
+	re, err := rule.Compile()
+	if err != nil {
+		panic(err)
+	}
+	return filter{name, rule, re}
+}
+
+func TestApplyFilters(t *testing.T) {
+	list := []filter{
+		compiled("links", config.Filter{Match: "domain", Patterns: []string{"spam.com"}, Action: config.FilterFlag}),
+		compiled("rude", config.Filter{Match: "word", Patterns: []string{"darn"}, Action: config.FilterReplace}),
+		compiled("shout", config.Filter{Match: "regex", Patterns: []string{`!{3,}`}, Action: config.FilterReview}),
+		compiled("worst", config.Filter{Match: "word", Patterns: []string{"heck"}, Action: config.FilterBlock}),
+	}
+
+	Convey("Content filters apply their actions over matches", t, func() {
+		Convey("Exact words are replaced with asterisks", func() {
+			v := applyFilters(list, "Darn it, darnit")
+			So(v.content, ShouldEqual, "**** it, darnit")
+			So(v.blocked, ShouldBeEmpty)
+		})
+
+		Convey("Domains match subdomains but not longer names", func() {
+			v := applyFilters(list, "visit http://www.spam.com/deal")
+			So(len(v.flag), ShouldEqual, 1)
+			v = applyFilters(list, "visit http://nospam.com/deal")
+			So(len(v.flag), ShouldEqual, 0)
+		})
+
+		Convey("Regex matches hold for review", func() {
+			v := applyFilters(list, "wow!!!")
+			So(len(v.review), ShouldEqual, 1)
+		})
+
+		Convey("Blocking stops the pipeline", func() {
+			v := applyFilters(list, "what the heck")
+			So(v.blocked, ShouldEqual, "worst")
+		})
+	})
+}
@@ -19,6 +19,7 @@ func Postprocess(d deps, c Parseable) (processed Parseable, err error) {
 	pipeline := []Processor{
 		postReplaceMentionTags,
 		postReplaceAssetTags,
+		postReplaceEmojiTags,
 	}
 
 	// Run pipeline over parseable.
@@ -40,8 +41,10 @@ func Postprocess(d deps, c Parseable) (processed Parseable, err error) {
 func Preprocess(d deps, c Parseable) (processed Parseable, err error) {
 	starts := time.Now()
 	pipeline := []Preprocessor{
+		preApplyFilters,
 		preReplaceMentionTags,
 		preReplaceAssetTags,
+		preReplaceEmojiTags,
 	}
 
 	// Run pipeline over parseable.
@@ -53,6 +53,20 @@ func NewFlag(id bson.ObjectId) Event {
 	}
 }
 
+// ResolveFlags over a post or comment, status tells how they were resolved.
+func ResolveFlags(sign UserSign, related string, id bson.ObjectId, flags []bson.ObjectId, status string) Event {
+	return Event{
+		Name: FLAGS_RESOLVED,
+		Sign: &sign,
+		Params: map[string]interface{}{
+			"related": related,
+			"id":      id,
+			"flags":   flags,
+			"status":  status,
+		},
+	}
+}
+
 func NewBanFlag(userID bson.ObjectId) Event {
 	return Event{
 		Name: NEW_BAN,
@@ -72,6 +86,41 @@ func DeletePost(sign UserSign, id bson.ObjectId) Event {
 	}
 }
 
+// UpdatePost by given sign, action tells which kind of revision it was.
+func UpdatePost(sign UserSign, id bson.ObjectId, action string) Event {
+	return Event{
+		Name: POST_UPDATED,
+		Sign: &sign,
+		Params: map[string]interface{}{
+			"id":     id,
+			"action": action,
+		},
+	}
+}
+
+// RestorePost from the trash.
+func RestorePost(sign UserSign, id bson.ObjectId) Event {
+	return Event{
+		Name: POST_RESTORED,
+		Sign: &sign,
+		Params: map[string]interface{}{
+			"id": id,
+		},
+	}
+}
+
+// RestoreComment from the trash.
+func RestoreComment(sign UserSign, postID, id bson.ObjectId) Event {
+	return Event{
+		Name: COMMENT_RESTORED,
+		Sign: &sign,
+		Params: map[string]interface{}{
+			"id":      id,
+			"post_id": postID,
+		},
+	}
+}
+
 func DeleteComment(sign UserSign, postId, id bson.ObjectId) Event {
 	return Event{
 		Name: COMMENT_DELETE,
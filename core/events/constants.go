@@ -6,10 +6,13 @@ const (
 	POST_VIEW       = "posts:view"
 	POSTS_REACHED   = "posts:reached"
 	POST_DELETED    = "posts:deleted"
+	POST_UPDATED    = "posts:updated"
+	POST_RESTORED   = "posts:restored"
 	RECENT_ACTIVITY = "activity:recent"
 
 	COMMENT_DELETE          = "comments:delete"
 	COMMENT_UPDATE          = "comments:update"
+	COMMENT_RESTORED        = "comments:restored"
 	COMMENT_UPVOTE          = "comments:upvote"
 	COMMENT_VOTE            = "comments:vote"
 	VOTE                    = "vote"
@@ -17,9 +20,10 @@ const (
 	COMMENT_DOWNVOTE        = "comments:downvote"
 	COMMENT_DOWNVOTE_REMOVE = "comments:downvote.remove"
 
-	NEW_FLAG    = "flag:new"
-	NEW_BAN     = "flag:ban"
-	NEW_MENTION = "new:mentions"
+	NEW_FLAG       = "flag:new"
+	NEW_BAN        = "flag:ban"
+	FLAGS_RESOLVED = "flag:resolved"
+	NEW_MENTION    = "new:mentions"
 
 	RAW_EMIT = "transmit:emit"
 )
@@ -23,6 +23,36 @@ func RunShell() {
 		Func: MigrateComments,
 	})
 
+	shell.AddCmd(&ishell.Cmd{
+		Name: "migrate-comment-trees",
+		Help: "Fill ancestors of replies stored before comment trees.",
+		Func: MigrateCommentTrees,
+	})
+
+	shell.AddCmd(&ishell.Cmd{
+		Name: "migrate-vote-owners",
+		Help: "Attribute reactions stored before reaction stats to their authors.",
+		Func: MigrateVoteOwners,
+	})
+
+	shell.AddCmd(&ishell.Cmd{
+		Name: "migrate-vote-awards",
+		Help: "Record what reactions given before reaction effects awarded.",
+		Func: MigrateVoteAwards,
+	})
+
+	shell.AddCmd(&ishell.Cmd{
+		Name: "migrate-post-counts",
+		Help: "Keep views and reach counted before unique sketches as the base.",
+		Func: MigratePostCounts,
+	})
+
+	shell.AddCmd(&ishell.Cmd{
+		Name: "migrate-flag-targets",
+		Help: "Attribute flags sent before the moderation queue to their post and category, mark system ones.",
+		Func: MigrateFlagTargets,
+	})
+
 	// start shell
 	shell.Start()
 
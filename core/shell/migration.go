@@ -2,8 +2,14 @@ package shell
 
 import (
 	"github.com/abiosoft/ishell"
+	"github.com/tryanzu/core/board/categories"
 	"github.com/tryanzu/core/board/comments"
+	"github.com/tryanzu/core/board/flags"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/votes"
+	"github.com/tryanzu/core/core/config"
 	"github.com/tryanzu/core/deps"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -27,3 +33,189 @@ func MigrateComments(c *ishell.Context) {
 	}
 	c.ProgressBar().Stop()
 }
+
+// MigrateCommentTrees fills the ancestors of replies stored before comment trees.
+func MigrateCommentTrees(c *ishell.Context) {
+	c.ShowPrompt(false)
+	defer c.ShowPrompt(true)
+
+	db := deps.Container.Mgo()
+	migratable := db.C("comments").Find(bson.M{"reply_type": "comment", "ancestors": bson.M{"$exists": false}}).Sort("_id").Iter()
+	var comment comments.Comment
+	c.ProgressBar().Indeterminate(true)
+	c.ProgressBar().Start()
+	for migratable.Next(&comment) {
+		ancestors, postID, err := comments.Ancestry(deps.Container, comment.ReplyTo)
+		if err != nil {
+			c.Println("Could not migrate comment", comment.Id.Hex(), err)
+			continue
+		}
+		err = db.C("comments").UpdateId(comment.Id, bson.M{"$set": bson.M{
+			"ancestors": ancestors,
+			"post_id":   postID,
+		}})
+		if err != nil {
+			c.Println("Could not migrate comment", err)
+		}
+	}
+	c.ProgressBar().Stop()
+}
+
+// MigrateVoteOwners attributes reactions stored before reaction stats to the
+// author, post and category of the reacted item.
+func MigrateVoteOwners(c *ishell.Context) {
+	c.ShowPrompt(false)
+	defer c.ShowPrompt(true)
+
+	db := deps.Container.Mgo()
+	migratable := db.C("votes").Find(bson.M{
+		"type":     bson.M{"$in": []string{"post", "comment"}},
+		"owner_id": bson.M{"$exists": false},
+	}).Sort("_id").Iter()
+	var vote votes.Vote
+	c.ProgressBar().Indeterminate(true)
+	c.ProgressBar().Start()
+	for migratable.Next(&vote) {
+		postID, owner := vote.RelatedID, bson.ObjectId("")
+		if vote.Type == "comment" {
+			comment, err := comments.FindId(deps.Container, vote.RelatedID)
+			if err != nil {
+				c.Println("Could not migrate vote", vote.ID.Hex(), err)
+				continue
+			}
+			postID, owner = comment.RelatedPost(), comment.UserId
+		}
+		post, err := posts.FindId(deps.Container, postID)
+		if err != nil {
+			c.Println("Could not migrate vote", vote.ID.Hex(), err)
+			continue
+		}
+		if vote.Type == "post" {
+			owner = post.UserId
+		}
+		_, err = votes.Attribute(deps.Container, vote, owner, post.Id, post.Category)
+		if err != nil {
+			c.Println("Could not migrate vote", err)
+		}
+	}
+	c.ProgressBar().Stop()
+}
+
+// MigrateVoteAwards records what active reactions given before configurable
+// effects awarded, so retracting them takes it back instead of paying twice
+// once they are given again. Run it after migrate-vote-owners.
+func MigrateVoteAwards(c *ishell.Context) {
+	c.ShowPrompt(false)
+	defer c.ShowPrompt(true)
+
+	db := deps.Container.Mgo()
+	migratable := db.C("votes").Find(bson.M{
+		"type":       bson.M{"$in": []string{"post", "comment"}},
+		"owner_id":   bson.M{"$exists": true},
+		"awarded":    bson.M{"$exists": false},
+		"deleted_at": bson.M{"$exists": false},
+	}).Sort("_id").Iter()
+	sets := map[bson.ObjectId][]string{}
+	rules := config.C.Rules()
+	var vote votes.Vote
+	c.ProgressBar().Indeterminate(true)
+	c.ProgressBar().Start()
+	for migratable.Next(&vote) {
+		if vote.UserID == vote.OwnerID {
+			continue
+		}
+		set, exists := sets[vote.Category]
+		if !exists {
+			category, err := categories.FindId(deps.Container, vote.Category)
+			if err != nil {
+				c.Println("Could not migrate vote", vote.ID.Hex(), err)
+				continue
+			}
+			set = category.ReactSet
+			sets[vote.Category] = set
+		}
+		awarded := votes.Awarded{}
+		if rule, exists := rules.Reaction(vote.Value, set); exists {
+			effect, err := rule.Effect(vote.Type, vote.Value)
+			if err != nil {
+				c.Println("Could not migrate vote", vote.ID.Hex(), err)
+				continue
+			}
+			awarded = votes.Awarded{Provider: effect.Provider, Receiver: effect.Receiver}
+		}
+		err := db.C("votes").Update(bson.M{"_id": vote.ID, "awarded": bson.M{"$exists": false}}, bson.M{
+			"$set": bson.M{"awarded": awarded},
+		})
+		if err != nil && err != mgo.ErrNotFound {
+			c.Println("Could not migrate vote", err)
+		}
+	}
+	c.ProgressBar().Stop()
+}
+
+// MigratePostCounts keeps the views and reach counted before unique
+// sketches as the base of every post counters.
+func MigratePostCounts(c *ishell.Context) {
+	c.ShowPrompt(false)
+	defer c.ShowPrompt(true)
+
+	db := deps.Container.Mgo()
+	migratable := db.C("posts").Find(nil).Select(bson.M{"_id": 1}).Sort("_id").Iter()
+	var post posts.Post
+	c.ProgressBar().Indeterminate(true)
+	c.ProgressBar().Start()
+	for migratable.Next(&post) {
+		err := posts.SeedCounts(deps.Container, post.Id)
+		if err != nil {
+			c.Println("Could not migrate post", post.Id.Hex(), err)
+		}
+	}
+	c.ProgressBar().Stop()
+}
+
+// MigrateFlagTargets attributes flags sent before the moderation queue to
+// the post and category of the flagged item, and marks the system ones.
+func MigrateFlagTargets(c *ishell.Context) {
+	c.ShowPrompt(false)
+	defer c.ShowPrompt(true)
+
+	db := deps.Container.Mgo()
+	_, err := db.C("flags").UpdateAll(bson.M{
+		"content": bson.RegEx{Pattern: "^System has sent this flag"},
+		"system":  bson.M{"$exists": false},
+	}, bson.M{"$set": bson.M{"system": true}})
+	if err != nil {
+		c.Println("Could not mark system flags", err)
+	}
+	migratable := db.C("flags").Find(bson.M{
+		"related_to": bson.M{"$in": []string{"post", "comment"}},
+		"category":   bson.M{"$exists": false},
+	}).Sort("_id").Iter()
+	var flag flags.Flag
+	c.ProgressBar().Indeterminate(true)
+	c.ProgressBar().Start()
+	for migratable.Next(&flag) {
+		if flag.RelatedID == nil {
+			continue
+		}
+		postID := *flag.RelatedID
+		if flag.RelatedTo == "comment" {
+			comment, err := comments.FindId(deps.Container, postID)
+			if err != nil {
+				c.Println("Could not migrate flag", flag.ID.Hex(), err)
+				continue
+			}
+			postID = comment.RelatedPost()
+		}
+		post, err := posts.FindId(deps.Container, postID)
+		if err != nil {
+			c.Println("Could not migrate flag", flag.ID.Hex(), err)
+			continue
+		}
+		_, err = flags.Attribute(deps.Container, flag, post.Id, post.Category)
+		if err != nil {
+			c.Println("Could not migrate flag", err)
+		}
+	}
+	c.ProgressBar().Stop()
+}
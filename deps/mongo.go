@@ -45,6 +45,63 @@ func IgniteMongoDB(container Deps) (Deps, error) {
 		Background:      true, // See notes.
 	}
 	db.C("posts").EnsureIndex(search)
+	db.C("comments").EnsureIndex(
+		mgo.Index{
+			Key:        []string{"ancestors"},
+			Background: true,
+		},
+	)
+	db.C("comments").EnsureIndex(
+		mgo.Index{
+			Key:        []string{"deleted_with"},
+			Sparse:     true,
+			Background: true,
+		},
+	)
+	db.C("votes").EnsureIndex(
+		mgo.Index{
+			Key:        []string{"owner_id", "created_at"},
+			Sparse:     true,
+			Background: true,
+		},
+	)
+	db.C("votes").EnsureIndex(
+		mgo.Index{
+			Key:        []string{"post_id"},
+			Sparse:     true,
+			Background: true,
+		},
+	)
+	db.C("vote_reports").EnsureIndex(
+		mgo.Index{
+			Key:        []string{"key", "status"},
+			Background: true,
+		},
+	)
+	db.C("flags").EnsureIndex(
+		mgo.Index{
+			Key:        []string{"status", "related_to", "related_id"},
+			Background: true,
+		},
+	)
+	db.C("flags").EnsureIndex(
+		mgo.Index{
+			Key:        []string{"category", "status"},
+			Background: true,
+		},
+	)
+	db.C("trusted_addresses").EnsureIndex(
+		mgo.Index{
+			Key:        []string{"address"},
+			Background: true,
+		},
+	)
+	db.C("trash").EnsureIndex(
+		mgo.Index{
+			Key:        []string{"deleted_at"},
+			Background: true,
+		},
+	)
 
 	// See https://godoc.org/gopkg.in/mgo.v2#Session.SetMode
 	//session.SetMode(mgo.Monotonic, true)
@@ -10,6 +10,7 @@ import (
 	"github.com/op/go-logging"
 	"github.com/spf13/cobra"
 	_ "github.com/tryanzu/core/board/events"
+	"github.com/tryanzu/core/board/jobs"
 	"github.com/tryanzu/core/core/config"
 	"github.com/tryanzu/core/core/shell"
 	"github.com/tryanzu/core/deps"
@@ -101,6 +102,9 @@ func main() {
 			// Populate dependencies using the already instantiated DI
 			api.Populate(g)
 
+			// Background jobs run along the API server.
+			jobs.Boot()
+
 			// Run API module
 			api.Run(port)
 		},
@@ -76,11 +76,26 @@ func (user *User) CanSolvePost(post *feed.Post) bool {
 	return user.isActionGranted(post.UserId, post.Category, "solve-own-posts", "solve-board-posts", "solve-category-posts")
 }
 
+// CanAcceptAnswer helper, same abilities as solving the post.
+func (user *User) CanAcceptAnswer(ownerID, categoryID bson.ObjectId) bool {
+	return user.isActionGranted(ownerID, categoryID, "solve-own-posts", "solve-board-posts", "solve-category-posts")
+}
+
 // Check if user can lock post
 func (user *User) CanLockPost(post *feed.Post) bool {
 	return user.isActionGranted(post.UserId, post.Category, "block-own-post-comments", "block-board-post-comments", "block-category-post-comments")
 }
 
+// CanModeratePost checks moderator abilities over posts of a category, regardless of their author.
+func (user *User) CanModeratePost(categoryID bson.ObjectId) bool {
+	return user.isActionGranted(bson.ObjectId(""), categoryID, "", "edit-board-posts", "edit-category-posts")
+}
+
+// CanClosePost checks moderator abilities to close posts of a category to new comments.
+func (user *User) CanClosePost(categoryID bson.ObjectId) bool {
+	return user.isActionGranted(bson.ObjectId(""), categoryID, "", "block-board-post-comments", "block-category-post-comments")
+}
+
 // Check if user can delete post
 func (user *User) CanDeletePost(post *feed.Post) bool {
 
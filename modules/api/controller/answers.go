@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tryanzu/core/board/bounties"
+	"github.com/tryanzu/core/board/comments"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/core/events"
+	"github.com/tryanzu/core/core/user"
+	"github.com/tryanzu/core/deps"
+	"github.com/tryanzu/core/modules/acl"
+	"github.com/tryanzu/core/modules/gaming"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AcceptAnswer marks a comment as the answer of its question, awarding any open bounty.
+// Questions whose bounty was awarded already keep their answer.
+func AcceptAnswer(c *gin.Context) {
+	comment, post, ok := answerParams(c)
+	if ok == false {
+		return
+	}
+	// Accepting another answer would unaccept the one awarded with a bounty.
+	bounty, err := bounties.FindByPost(deps.Container, post.Id)
+	if err == nil && bounty.Status == bounties.StatusAwarded && bounty.CommentID != nil && *bounty.CommentID != comment.Id {
+		jsonErr(c, http.StatusConflict, "The question has an answer awarded with a bounty already")
+		return
+	}
+	err = comments.Accept(deps.Container, comment)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	coins := 0
+	if bounty, err := bounties.FindOpen(deps.Container, post.Id); err == nil {
+		bounty, err = bounties.Award(deps.Container, bounty, comment.Id, comment.UserId)
+		if err != nil && err != bounties.BountyClosed {
+			jsonErr(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if bounty.Status == bounties.StatusAwarded {
+			coins = bounty.Coins
+		}
+	}
+
+	events.In <- events.RawEmit("post."+post.Id.Hex(), "answer", map[string]interface{}{
+		"fire":       "answer-accepted",
+		"comment_id": comment.Id.Hex(),
+		"user_id":    comment.UserId.Hex(),
+		"bounty":     coins,
+	})
+	events.In <- events.UpdatePost(signs(c), post.Id, "accept-answer")
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "bounty": coins})
+}
+
+// UnacceptAnswer removes the accepted mark. Answers that got a bounty stay accepted.
+func UnacceptAnswer(c *gin.Context) {
+	comment, post, ok := answerParams(c)
+	if ok == false {
+		return
+	}
+	if comment.Chosen == false {
+		jsonErr(c, http.StatusBadRequest, "Comment is not the accepted answer")
+		return
+	}
+	bounty, err := bounties.FindByPost(deps.Container, post.Id)
+	if err == nil && bounty.Status == bounties.StatusAwarded && bounty.CommentID != nil && *bounty.CommentID == comment.Id {
+		jsonErr(c, http.StatusConflict, "Answers awarded with a bounty can't be unaccepted")
+		return
+	}
+	err = comments.Unaccept(deps.Container, comment)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	events.In <- events.RawEmit("post."+post.Id.Hex(), "answer", map[string]interface{}{
+		"fire":       "answer-unaccepted",
+		"comment_id": comment.Id.Hex(),
+	})
+	events.In <- events.UpdatePost(signs(c), post.Id, "unaccept-answer")
+	c.JSON(http.StatusOK, gin.H{"status": "okay"})
+}
+
+func answerParams(c *gin.Context) (comment comments.Comment, post posts.Post, ok bool) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid comment id")
+		return
+	}
+	comment, err := comments.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil || comment.Deleted != nil {
+		jsonErr(c, http.StatusNotFound, "unknown comment")
+		return
+	}
+	post, err = posts.FindId(deps.Container, comment.RelatedPost())
+	if err != nil || post.Deleted.IsZero() == false {
+		jsonErr(c, http.StatusNotFound, "unknown comment's post")
+		return
+	}
+	if post.IsQuestion == false {
+		jsonErr(c, http.StatusBadRequest, "Only questions can have an accepted answer")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	if acl.LoadedACL.User(usr.Id).CanAcceptAnswer(post.UserId, post.Category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	return comment, post, true
+}
+
+// OfferBounty escrows coins from the asker until an answer gets accepted or the bounty expires.
+func OfferBounty(c *gin.Context) {
+	var form struct {
+		Coins int `json:"coins" binding:"required,min=1"`
+		Days  int `json:"days" binding:"max=30"`
+	}
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	if err := c.BindJSON(&form); err != nil {
+		jsonErr(c, http.StatusBadRequest, "Invalid bounty request, check parameters")
+		return
+	}
+	post, err := posts.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil || post.Deleted.IsZero() == false {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	if post.UserId != usr.Id {
+		jsonErr(c, http.StatusForbidden, "Only the question author can offer a bounty")
+		return
+	}
+	if post.IsQuestion == false || post.Solved {
+		jsonErr(c, http.StatusBadRequest, "Bounties can only be offered for unsolved questions")
+		return
+	}
+	if form.Days <= 0 {
+		form.Days = 7
+	}
+	bounty, err := bounties.Offer(deps.Container, post.Id, usr.Id, form.Coins, time.Now().AddDate(0, 0, form.Days))
+	if err == gaming.NotEnoughCoins {
+		jsonErr(c, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	if err == bounties.InvalidBounty || err == bounties.BountyExists {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	events.In <- events.RawEmit("post."+post.Id.Hex(), "bounty", map[string]interface{}{
+		"fire":       "bounty-offered",
+		"coins":      bounty.Coins,
+		"expires_at": bounty.Expires,
+	})
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "bounty": bounty})
+}
+
+// PostBounty gets the latest bounty of a post.
+func PostBounty(c *gin.Context) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	bounty, err := bounties.FindByPost(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, bounty)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tryanzu/core/board/bookmarks"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/core/user"
+	"github.com/tryanzu/core/deps"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Bookmarks of signed user paginated by cursor, optionally within a collection.
+func Bookmarks(c *gin.Context) {
+	page := bookmarks.Page{
+		Related: c.Query("related"),
+		Limit:   10,
+	}
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 && n <= 50 {
+		page.Limit = n
+	}
+	if name, exists := c.GetQuery("collection"); exists {
+		page.Collection = &name
+	}
+	if id := c.Query("before"); bson.IsObjectIdHex(id) {
+		before := bson.ObjectIdHex(id)
+		page.Before = &before
+	}
+	usr := c.MustGet("user").(user.User)
+	list, next, err := bookmarks.FindList(deps.Container, usr.Id, page)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"bookmarks": list, "next": next})
+}
+
+// BookmarkCollections of signed user.
+func BookmarkCollections(c *gin.Context) {
+	usr := c.MustGet("user").(user.User)
+	list, err := bookmarks.FindCollections(deps.Container, usr.Id)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"collections": list})
+}
+
+// SaveBookmark of a post or comment.
+func SaveBookmark(c *gin.Context) {
+	var form struct {
+		Collection string `json:"collection"`
+	}
+	related, id := c.Param("related"), c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	// The collection is optional, so is the body.
+	if err := c.ShouldBindJSON(&form); err != nil && err != io.EOF {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, check the bookmark collection.")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	b, err := bookmarks.Save(deps.Container, usr.Id, related, bson.ObjectIdHex(id), form.Collection)
+	if err == bookmarks.InvalidBookmark {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if related == "post" {
+		if err := posts.SyncRanks(deps.Container, []bson.ObjectId{b.RelatedID}); err != nil {
+			log.Errorf("syncing bookmarked post ranks failed	id=%v err=%v", b.RelatedID.Hex(), err)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "bookmark": b})
+}
+
+// RemoveBookmark of a post or comment.
+func RemoveBookmark(c *gin.Context) {
+	related, id := c.Param("related"), c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	err := bookmarks.Remove(deps.Container, usr.Id, related, bson.ObjectIdHex(id))
+	if err == bookmarks.BookmarkNotFound {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if related == "post" {
+		if err := posts.SyncRanks(deps.Container, []bson.ObjectId{bson.ObjectIdHex(id)}); err != nil {
+			log.Errorf("syncing unbookmarked post ranks failed	id=%v err=%v", id, err)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay"})
+}
@@ -1,10 +1,13 @@
 package controller
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/tryanzu/core/board/categories"
 	"github.com/tryanzu/core/deps"
 	"github.com/tryanzu/core/modules/acl"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -18,3 +21,31 @@ func Categories(c *gin.Context) {
 	}
 	c.JSON(200, tree)
 }
+
+// UpdateCategoryPolicy for inactive posts of a category.
+func UpdateCategoryPolicy(c *gin.Context) {
+	var form struct {
+		LockAfter    int `json:"lock_after" binding:"min=0,max=3650"`
+		ArchiveAfter int `json:"archive_after" binding:"min=0,max=120"`
+	}
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid category id")
+		return
+	}
+	if err := c.BindJSON(&form); err != nil {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	policy := categories.Policy{LockAfter: form.LockAfter, ArchiveAfter: form.ArchiveAfter}
+	err := categories.UpdatePolicy(deps.Container, bson.ObjectIdHex(id), policy)
+	if err == mgo.ErrNotFound {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the category")
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "policy": policy})
+}
@@ -5,18 +5,33 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tryanzu/core/board/comments"
 	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/ranking"
+	"github.com/tryanzu/core/core/config"
+	"github.com/tryanzu/core/core/content"
 	"github.com/tryanzu/core/core/events"
 	"github.com/tryanzu/core/core/user"
 	"github.com/tryanzu/core/deps"
+	"github.com/tryanzu/core/modules/acl"
 	"gopkg.in/mgo.v2/bson"
 )
 
-// Comments paginated fetch.
+// Comments paginated fetch. Old clients get top level comments with a single
+// level of replies, while tree and flat modes cover the whole threads.
 func Comments(c *gin.Context) {
+	switch c.Query("mode") {
+	case "tree":
+		CommentsTree(c)
+		return
+	case "flat":
+		CommentsFlat(c)
+		return
+	}
+
 	var (
 		pid    = c.Param("post_id")
 		limit  = 10
@@ -44,10 +59,18 @@ func Comments(c *gin.Context) {
 		after = &id
 	}
 
-	set, err := comments.FetchBy(
-		deps.Container,
-		comments.Post(bson.ObjectIdHex(pid), limit, offset, sort == "reverse", before, after),
+	var (
+		set comments.CommentsSet
+		err error
 	)
+	if comments.Sorts[sort] {
+		set, err = comments.FindSorted(deps.Container, bson.ObjectIdHex(pid), sort, reactionWeights(), limit, offset)
+	} else {
+		set, err = comments.FetchBy(
+			deps.Container,
+			comments.Post(bson.ObjectIdHex(pid), limit, offset, sort == "reverse", before, after),
+		)
+	}
 	if err != nil {
 		c.AbortWithError(500, err)
 		return
@@ -84,6 +107,174 @@ func Comments(c *gin.Context) {
 	c.JSON(200, gin.H{"status": "okay", "count": set.Count, "list": list.IDList(), "hashtables": tables})
 }
 
+// CommentsTree of a post down to given depth. Passing a parent comment loads
+// more of its replies, after the given cursor.
+func CommentsTree(c *gin.Context) {
+	var (
+		pid     = c.Param("post_id")
+		limit   = 10
+		offset  = 0
+		depth   = 3
+		replies = 5
+		before  *bson.ObjectId
+		after   *bson.ObjectId
+	)
+	if bson.IsObjectIdHex(pid) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 && n <= 50 {
+		limit = n
+	}
+	if n, err := strconv.Atoi(c.Query("offset")); err == nil && n >= 0 {
+		offset = n
+	}
+	if n, err := strconv.Atoi(c.Query("depth")); err == nil && n >= 0 && n <= 10 {
+		depth = n
+	}
+	if n, err := strconv.Atoi(c.Query("replies")); err == nil && n > 0 && n <= 20 {
+		replies = n
+	}
+	if id := c.Query("before"); bson.IsObjectIdHex(id) {
+		cursor := bson.ObjectIdHex(id)
+		before = &cursor
+	}
+	if id := c.Query("after"); bson.IsObjectIdHex(id) {
+		cursor := bson.ObjectIdHex(id)
+		after = &cursor
+	}
+
+	var (
+		set comments.CommentsSet
+		err error
+	)
+	parent, sort := c.Query("parent"), c.Query("sort")
+	switch {
+	case len(parent) > 0:
+		if bson.IsObjectIdHex(parent) == false {
+			jsonErr(c, http.StatusBadRequest, "Invalid parent comment id")
+			return
+		}
+		set, err = comments.FetchBy(deps.Container, comments.RepliesTo(bson.ObjectIdHex(parent), limit, after))
+	case comments.Sorts[sort]:
+		set, err = comments.FindSorted(deps.Container, bson.ObjectIdHex(pid), sort, reactionWeights(), limit, offset)
+	default:
+		set, err = comments.FetchBy(deps.Container, comments.Post(bson.ObjectIdHex(pid), limit, offset, false, before, after))
+	}
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	roots, err := set.List.WithUsers(deps.Container)
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	tree, err := comments.FindTree(deps.Container, roots, depth, replies)
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	votes := map[string][]string{}
+	if userID, exists := c.Get("userID"); exists {
+		voted, err := tree.Comments().VotesOf(deps.Container, userID.(bson.ObjectId))
+		if err != nil {
+			c.AbortWithError(500, err)
+			return
+		}
+		votes = voted.ValuesMap()
+	}
+	c.JSON(200, gin.H{"status": "okay", "count": set.Count, "list": tree, "votes": votes})
+}
+
+// CommentsFlat of a post with every reply, oldest first.
+func CommentsFlat(c *gin.Context) {
+	var (
+		pid   = c.Param("post_id")
+		limit = 50
+		after *bson.ObjectId
+	)
+	if bson.IsObjectIdHex(pid) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 && n <= 100 {
+		limit = n
+	}
+	if id := c.Query("after"); bson.IsObjectIdHex(id) {
+		cursor := bson.ObjectIdHex(id)
+		after = &cursor
+	}
+	set, err := comments.FetchBy(deps.Container, comments.Flat(bson.ObjectIdHex(pid), limit, after))
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	list, err := set.List.WithUsers(deps.Container)
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	var next *bson.ObjectId
+	if len(list) == limit {
+		next = &list[len(list)-1].Id
+	}
+	votes := map[string][]string{}
+	if userID, exists := c.Get("userID"); exists {
+		voted, err := list.VotesOf(deps.Container, userID.(bson.ObjectId))
+		if err != nil {
+			c.AbortWithError(500, err)
+			return
+		}
+		votes = voted.ValuesMap()
+	}
+	c.JSON(200, gin.H{"status": "okay", "count": set.Count, "list": list, "next": next, "votes": votes})
+}
+
+// CommentContext to show a single comment within its thread.
+func CommentContext(c *gin.Context) {
+	pid, cid := c.Param("post_id"), c.Param("comment_id")
+	if bson.IsObjectIdHex(pid) == false || bson.IsObjectIdHex(cid) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	n := 3
+	if v, err := strconv.Atoi(c.Query("n")); err == nil && v > 0 && v <= 20 {
+		n = v
+	}
+	ctx, err := comments.FindContext(deps.Container, bson.ObjectIdHex(pid), bson.ObjectIdHex(cid), n)
+	if err == comments.CommentNotFound {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	all, err := ctx.All().WithUsers(deps.Container)
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	ctx.Comment, all = all[0], all[1:]
+	ctx.Parents, all = all[:len(ctx.Parents)], all[len(ctx.Parents):]
+	ctx.Before, ctx.After = all[:len(ctx.Before)], all[len(ctx.Before):]
+	votes := map[string][]string{}
+	if userID, exists := c.Get("userID"); exists {
+		voted, err := ctx.All().VotesOf(deps.Container, userID.(bson.ObjectId))
+		if err != nil {
+			c.AbortWithError(500, err)
+			return
+		}
+		votes = voted.ValuesMap()
+	}
+	c.JSON(200, gin.H{"status": "okay", "context": ctx, "votes": votes})
+}
+
+func reactionWeights() ranking.Weights {
+	return ranking.Weights(config.C.Rules().ReactionWeights())
+}
+
 // NewComment pushes a new reply.
 func NewComment(c *gin.Context) {
 	var (
@@ -114,6 +305,24 @@ func NewComment(c *gin.Context) {
 		jsonErr(c, http.StatusBadRequest, "invalid comment length")
 		return
 	}
+	pid := cid
+	if kind == "comment" {
+		parent, err := comments.FindId(deps.Container, cid)
+		if err != nil {
+			jsonErr(c, http.StatusNotFound, "Couldnt find the comment")
+			return
+		}
+		pid = parent.RelatedPost()
+	}
+	post, err := posts.FindId(deps.Container, pid)
+	if err != nil || post.Deleted.IsZero() == false {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	if post.Lock {
+		jsonErr(c, http.StatusForbidden, "This post is closed to new comments")
+		return
+	}
 	usr := c.MustGet("user").(user.User)
 	comment, err := comments.UpsertComment(deps.Container, comments.Comment{
 		UserId:    usr.Id,
@@ -121,12 +330,22 @@ func NewComment(c *gin.Context) {
 		ReplyType: kind,
 		ReplyTo:   cid,
 	})
+	if _, blocked := err.(content.Blocked); blocked {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	if err != nil {
 		c.AbortWithError(500, errors.New("Invalid kind of reply"))
 		return
 	}
 
+	// Held comments stay out of sight (and events) until reviewed.
+	if comment.Deleted != nil {
+		c.JSON(http.StatusAccepted, gin.H{"status": "review", "comment": comment})
+		return
+	}
+
 	events.In <- events.PostComment(comment.Id)
 	c.JSON(200, comment)
 }
@@ -137,6 +356,7 @@ func UpdateComment(c *gin.Context) {
 		cid  = bson.ObjectIdHex(c.Param("id"))
 		form struct {
 			Content string `json:"content" binding:"required" validate:"min=2,max=25000"`
+			Reason  string `json:"reason" binding:"max=200"`
 		}
 	)
 
@@ -153,7 +373,7 @@ func UpdateComment(c *gin.Context) {
 		c.AbortWithError(404, errors.New("unknown comment to update"))
 		return
 	}
-	post, err := posts.FindId(deps.Container, comment.PostId)
+	post, err := posts.FindId(deps.Container, comment.RelatedPost())
 	if err != nil {
 		c.AbortWithError(404, errors.New("unknown comment's post to update"))
 		return
@@ -162,18 +382,106 @@ func UpdateComment(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "error", "message": "Not allowed to perform this operation"})
 		return
 	}
+	if post.Archived != nil {
+		jsonErr(c, http.StatusForbidden, "Archived posts are read-only.")
+		return
+	}
+
+	// Edits are tracked once the grace window is over, moderators' always are.
+	before := comment
+	usr := c.MustGet("userID").(bson.ObjectId)
+	moderated := usr != comment.UserId
+	tracked := moderated || comment.InGrace(time.Now()) == false
+	if tracked {
+		now := time.Now()
+		comment.Edited = &now
+	}
+	// Only the last editor is shown, the author's own edits clear moderators'.
+	comment.EditedBy = nil
+	if moderated {
+		comment.EditedBy = &usr
+	}
 	comment.Content = form.Content
 	updated, err := comments.UpsertComment(deps.Container, comment)
+	if _, blocked := err.(content.Blocked); blocked {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		c.AbortWithError(500, err)
 		return
 	}
 
+	// An edit held for review takes the comment out of the thread count.
+	if comment.Deleted == nil && updated.Deleted != nil && updated.ReplyType == "post" {
+		err = deps.Container.Mgo().C("posts").UpdateId(updated.ReplyTo, bson.M{"$inc": bson.M{"comments.count": -1}})
+		if err != nil {
+			c.AbortWithError(500, err)
+			return
+		}
+	}
+
+	if tracked {
+		stored, err := comments.FindId(deps.Container, comment.Id)
+		if err != nil {
+			c.AbortWithError(500, err)
+			return
+		}
+		_, err = comments.TrackRevision(deps.Container, before, comments.Revision{
+			UserID:    usr,
+			Content:   stored.Content,
+			Reason:    form.Reason,
+			Moderated: moderated,
+		})
+		if err != nil {
+			c.AbortWithError(500, err)
+			return
+		}
+	}
+
 	// Notify other processes...
-	events.In <- events.UpdateComment(signs(c), comment.ReplyTo, comment.Id)
+	sign := signs(c)
+	if len(form.Reason) > 0 {
+		sign.Reason = form.Reason
+	}
+	events.In <- events.UpdateComment(sign, comment.ReplyTo, comment.Id)
 	c.JSON(200, updated)
 }
 
+// CommentRevisions lists the edit history of a comment. It is shown to
+// moderators and the author, unless the site makes it public.
+func CommentRevisions(c *gin.Context) {
+	// Shares its wildcard with the comments of a post route.
+	id := c.Param("post_id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid comment id")
+		return
+	}
+	comment, err := comments.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil || comment.Deleted != nil {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the comment")
+		return
+	}
+	if config.C.Copy().Site.PublicEditHistory == false {
+		post, err := posts.FindId(deps.Container, comment.RelatedPost())
+		if err != nil {
+			jsonErr(c, http.StatusNotFound, "Couldnt find the comment's post")
+			return
+		}
+		uid, exists := c.Get("userID")
+		if !exists || acl.LoadedACL.User(uid.(bson.ObjectId)).CanUpdateComment(comment.UserId, post.Category) == false {
+			jsonErr(c, http.StatusForbidden, "Not allowed to see this comment history")
+			return
+		}
+	}
+	list, err := comments.FindRevisions(deps.Container, comment.Id)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": list})
+}
+
 // DeleteComment endpoint handler.
 func DeleteComment(c *gin.Context) {
 	var (
@@ -191,7 +499,7 @@ func DeleteComment(c *gin.Context) {
 		return
 	}
 
-	post, err := posts.FindId(deps.Container, comment.ReplyTo)
+	post, err := posts.FindId(deps.Container, comment.RelatedPost())
 	if err != nil {
 		c.AbortWithError(404, errors.New("unknown comment's post to update"))
 		return
@@ -201,14 +509,19 @@ func DeleteComment(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"status": "error", "message": "Not allowed to perform this operation"})
 		return
 	}
+	if post.Archived != nil {
+		jsonErr(c, http.StatusForbidden, "Archived posts are read-only.")
+		return
+	}
 
-	err = comments.Delete(deps.Container, comment)
+	sign := signs(c)
+	err = comments.Delete(deps.Container, comment, sign.UserID)
 	if err != nil {
 		c.AbortWithError(500, err)
 		return
 	}
 	// Notify events pool.
-	events.In <- events.DeleteComment(signs(c), comment.PostId, comment.Id)
+	events.In <- events.DeleteComment(sign, comment.RelatedPost(), comment.Id)
 	c.JSON(200, gin.H{"status": "okay"})
 }
 
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"html"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tryanzu/core/board/drafts"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/core/user"
+	"github.com/tryanzu/core/deps"
+	"github.com/tryanzu/core/modules/acl"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type draftForm struct {
+	ID         string `json:"id"`
+	Title      string `json:"title" binding:"max=200"`
+	Content    string `json:"content" binding:"max=25000"`
+	Category   string `json:"category"`
+	IsQuestion bool   `json:"is_question"`
+}
+
+// Drafts of signed user along with their scheduled posts.
+func Drafts(c *gin.Context) {
+	usr := c.MustGet("user").(user.User)
+	list, err := drafts.FindList(deps.Container, usr.Id)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	scheduled, err := posts.FindScheduled(deps.Container, usr.Id)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"drafts": list, "scheduled": scheduled})
+}
+
+// SaveDraft autosaves a new or existing draft.
+func SaveDraft(c *gin.Context) {
+	var form draftForm
+	if err := c.BindJSON(&form); err != nil {
+		jsonErr(c, http.StatusBadRequest, "Invalid draft request, check parameters")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	draft := drafts.Draft{
+		UserID:     usr.Id,
+		Title:      form.Title,
+		Content:    form.Content,
+		IsQuestion: form.IsQuestion,
+	}
+	if bson.IsObjectIdHex(form.ID) {
+		draft.ID = bson.ObjectIdHex(form.ID)
+	}
+	if bson.IsObjectIdHex(form.Category) {
+		category := bson.ObjectIdHex(form.Category)
+		draft.Category = &category
+	}
+	draft, err := drafts.UpsertDraft(deps.Container, draft)
+	if err == drafts.DraftNotFound {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if err == drafts.TooManyDrafts {
+		jsonErr(c, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "draft": draft})
+}
+
+// DeleteDraft of signed user.
+func DeleteDraft(c *gin.Context) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid draft id")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	err := drafts.Delete(deps.Container, usr.Id, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay"})
+}
+
+// scheduledPost from request params, only its author or moderators of its
+// category can handle it before it gets published.
+func scheduledPost(c *gin.Context) (post posts.Post, ok bool) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	post, err := posts.FindScheduledId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	if post.UserId != usr.Id && acl.LoadedACL.User(usr.Id).CanModeratePost(post.Category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	return post, true
+}
+
+// ReschedulePost not published yet.
+func ReschedulePost(c *gin.Context) {
+	var form struct {
+		PublishAt time.Time `json:"publish_at" binding:"required"`
+	}
+	post, ok := scheduledPost(c)
+	if !ok {
+		return
+	}
+	if err := c.BindJSON(&form); err != nil {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, a publishing time is needed.")
+		return
+	}
+	now := time.Now()
+	if form.PublishAt.Before(now) || form.PublishAt.After(now.AddDate(0, 0, posts.MaxScheduleDays)) {
+		jsonErr(c, http.StatusBadRequest, "Invalid publishing time, it must be in the future and not that far ahead.")
+		return
+	}
+	err := posts.Reschedule(deps.Container, post.Id, form.PublishAt)
+	if err == posts.ScheduledNotFound {
+		jsonErr(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "publish_at": form.PublishAt})
+}
+
+// CancelScheduledPost before it gets published. It goes back to its author
+// drafts, so it can be edited and scheduled again.
+func CancelScheduledPost(c *gin.Context) {
+	post, ok := scheduledPost(c)
+	if !ok {
+		return
+	}
+	draft, err := drafts.UpsertDraft(deps.Container, drafts.Draft{
+		UserID:     post.UserId,
+		Title:      post.Title,
+		Content:    html.UnescapeString(post.Content),
+		Category:   &post.Category,
+		IsQuestion: post.IsQuestion,
+	})
+	if err == drafts.TooManyDrafts {
+		jsonErr(c, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	err = posts.Unschedule(deps.Container, post.Id)
+	if err != nil {
+		drafts.Delete(deps.Container, post.UserId, draft.ID)
+	}
+	if err == posts.ScheduledNotFound {
+		jsonErr(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "draft": draft})
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tryanzu/core/board/emojis"
+	"github.com/tryanzu/core/board/realtime"
+	"github.com/tryanzu/core/core/user"
+	"github.com/tryanzu/core/deps"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const maxEmojiSize = 256 * 1024
+
+// Emojis list of usable custom emojis.
+func Emojis(c *gin.Context) {
+	list, err := emojis.FindList(deps.Container)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// UploadEmoji image with its shortcode & category restrictions.
+func UploadEmoji(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		jsonErr(c, http.StatusBadRequest, "Could not get the file...")
+		return
+	}
+	defer file.Close()
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		jsonErr(c, http.StatusBadRequest, "Could not read the file contents...")
+		return
+	}
+	if len(data) > maxEmojiSize {
+		jsonErr(c, http.StatusBadRequest, "Emoji image is too big, max 256KB")
+		return
+	}
+	if strings.HasPrefix(http.DetectContentType(data), "image") == false {
+		jsonErr(c, http.StatusBadRequest, "Could not detect an image file...")
+		return
+	}
+	categories, ok := emojiCategories(c.PostFormArray("categories"))
+	if ok == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid category id")
+		return
+	}
+
+	usr := c.MustGet("user").(user.User)
+	emoji, err := emojis.Upload(deps.Container, usr.Id, c.PostForm("shortcode"), header.Filename, data, categories)
+	if err == emojis.InvalidShortcode || err == emojis.ShortcodeTaken {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	realtime.BroadcastConfig()
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "emoji": emoji})
+}
+
+// UpdateEmoji shortcode & category restrictions.
+func UpdateEmoji(c *gin.Context) {
+	var form struct {
+		Shortcode  string   `json:"shortcode" binding:"required"`
+		Categories []string `json:"categories"`
+	}
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid emoji id")
+		return
+	}
+	if err := c.BindJSON(&form); err != nil {
+		jsonErr(c, http.StatusBadRequest, "Invalid emoji request, check parameters")
+		return
+	}
+	categories, ok := emojiCategories(form.Categories)
+	if ok == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid category id")
+		return
+	}
+	emoji, err := emojis.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	emoji, err = emojis.UpdateEmoji(deps.Container, emoji, form.Shortcode, categories)
+	if err == emojis.InvalidShortcode || err == emojis.ShortcodeTaken {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	realtime.BroadcastConfig()
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "emoji": emoji})
+}
+
+// DeleteEmoji so it can no longer be used.
+func DeleteEmoji(c *gin.Context) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid emoji id")
+		return
+	}
+	emoji, err := emojis.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	err = emojis.Delete(deps.Container, emoji)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	realtime.BroadcastConfig()
+	c.JSON(http.StatusOK, gin.H{"status": "okay"})
+}
+
+func emojiCategories(list []string) (categories []bson.ObjectId, ok bool) {
+	for _, id := range list {
+		if len(id) == 0 {
+			continue
+		}
+		if bson.IsObjectIdHex(id) == false {
+			return nil, false
+		}
+		categories = append(categories, bson.ObjectIdHex(id))
+	}
+	return categories, true
+}
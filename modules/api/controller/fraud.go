@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tryanzu/core/board/fraud"
+	"github.com/tryanzu/core/deps"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// VoteReports of suspicious votes paginated by cursor, pending ones by default.
+func VoteReports(c *gin.Context) {
+	limit := 20
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 && n <= 100 {
+		limit = n
+	}
+	var before *bson.ObjectId
+	if id := c.Query("before"); bson.IsObjectIdHex(id) {
+		bid := bson.ObjectIdHex(id)
+		before = &bid
+	}
+	status := c.DefaultQuery("status", fraud.StatusPending)
+	list, next, err := fraud.FindList(deps.Container, status, c.Query("kind"), before, limit)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"list": list, "next": next})
+}
+
+// pendingVoteReport from request params.
+func pendingVoteReport(c *gin.Context) (r fraud.Report, ok bool) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid report id")
+		return
+	}
+	r, err := fraud.FindOne(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if r.Status != fraud.StatusPending {
+		jsonErr(c, http.StatusConflict, "Report has already been resolved")
+		return
+	}
+	return r, true
+}
+
+// NeutralizeVoteReport votes and take back what they awarded.
+func NeutralizeVoteReport(c *gin.Context) {
+	r, ok := pendingVoteReport(c)
+	if !ok {
+		return
+	}
+	by := c.MustGet("userID").(bson.ObjectId)
+	r, err := fraud.Neutralize(deps.Container, r, &by)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, r)
+}
+
+// DismissVoteReport as a false positive, its pattern won't be reported again.
+func DismissVoteReport(c *gin.Context) {
+	r, ok := pendingVoteReport(c)
+	if !ok {
+		return
+	}
+	by := c.MustGet("userID").(bson.ObjectId)
+	r, err := fraud.Dismiss(deps.Container, r, &by)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, r)
+}
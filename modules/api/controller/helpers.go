@@ -2,10 +2,13 @@ package controller
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/op/go-logging"
 	"github.com/tryanzu/core/modules/acl"
 	"gopkg.in/go-playground/validator.v8"
 )
 
+var log = logging.MustGetLogger("http-api")
+
 func jsonErr(c *gin.Context, status int, message string) {
 	// This specific json error structure is handled
 	// by the frontend in a generic way so errors
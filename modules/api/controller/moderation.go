@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/threads"
+	"github.com/tryanzu/core/core/events"
+	"github.com/tryanzu/core/core/user"
+	"github.com/tryanzu/core/deps"
+	"github.com/tryanzu/core/modules/acl"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// moderatedPost from request params, published and not deleted.
+func moderatedPost(c *gin.Context) (post posts.Post, ok bool) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	post, err := posts.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil || post.Deleted.IsZero() == false {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	return post, true
+}
+
+// MovePost into another category.
+func MovePost(c *gin.Context) {
+	var form struct {
+		Category string `json:"category" binding:"required"`
+	}
+	post, ok := moderatedPost(c)
+	if !ok {
+		return
+	}
+	if err := c.BindJSON(&form); err != nil || bson.IsObjectIdHex(form.Category) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	category := bson.ObjectIdHex(form.Category)
+	usr := c.MustGet("user").(user.User)
+	perms := acl.LoadedACL.User(usr.Id)
+	if perms.CanModeratePost(post.Category) == false || perms.CanModeratePost(category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	err := threads.Move(deps.Container, post, category)
+	if err == threads.InvalidMove {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	events.In <- events.RawEmit("feed", "action", map[string]interface{}{
+		"fire":     "moved-post",
+		"id":       post.Id.Hex(),
+		"from":     post.Category.Hex(),
+		"category": category.Hex(),
+	})
+	events.In <- events.UpdatePost(signs(c), post.Id, "move")
+	c.JSON(http.StatusOK, gin.H{"status": "okay"})
+}
+
+// MergePost folds the post comments into another post.
+func MergePost(c *gin.Context) {
+	var form struct {
+		Into string `json:"into" binding:"required"`
+	}
+	from, ok := moderatedPost(c)
+	if !ok {
+		return
+	}
+	if err := c.BindJSON(&form); err != nil || bson.IsObjectIdHex(form.Into) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	into, err := posts.FindId(deps.Container, bson.ObjectIdHex(form.Into))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post to merge into")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	perms := acl.LoadedACL.User(usr.Id)
+	if perms.CanModeratePost(from.Category) == false || perms.CanModeratePost(into.Category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	err = threads.Merge(deps.Container, from, into)
+	if err == threads.InvalidMerge {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	events.In <- events.RawEmit("feed", "action", map[string]interface{}{
+		"fire": "merged-post",
+		"id":   from.Id.Hex(),
+		"into": into.Id.Hex(),
+		"slug": into.Slug,
+	})
+	sign := signs(c)
+	events.In <- events.UpdatePost(sign, from.Id, "merged")
+	events.In <- events.UpdatePost(sign, into.Id, "merge")
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "id": into.Id, "slug": into.Slug})
+}
+
+// SplitPost turns some of its comments into a new post.
+func SplitPost(c *gin.Context) {
+	var form struct {
+		Comments []bson.ObjectId `json:"comments" binding:"required"`
+		Title    string          `json:"title" binding:"required"`
+		Category string          `json:"category"`
+	}
+	post, ok := moderatedPost(c)
+	if !ok {
+		return
+	}
+	if err := c.BindJSON(&form); err != nil {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	category := post.Category
+	if bson.IsObjectIdHex(form.Category) {
+		category = bson.ObjectIdHex(form.Category)
+	}
+	usr := c.MustGet("user").(user.User)
+	perms := acl.LoadedACL.User(usr.Id)
+	if perms.CanModeratePost(post.Category) == false || perms.CanModeratePost(category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	split, err := threads.Split(deps.Container, post, form.Comments, form.Title, category)
+	if err == threads.InvalidSplit {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	events.In <- events.RawEmit("feed", "action", map[string]interface{}{
+		"fire":     "split-post",
+		"id":       post.Id.Hex(),
+		"split":    split.Id.Hex(),
+		"category": split.Category.Hex(),
+		"slug":     split.Slug,
+	})
+	events.In <- events.RawEmit("post", post.Id.Hex(), map[string]interface{}{
+		"fire": "updated",
+	})
+	events.In <- events.UpdatePost(signs(c), post.Id, "split")
+	events.In <- events.PostNew(split.Id)
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "post": gin.H{"id": split.Id, "slug": split.Slug}})
+}
+
+// ClosePost to new comments with a reason and optional reopening time.
+func ClosePost(c *gin.Context) {
+	var form struct {
+		Reason string     `json:"reason" binding:"required,max=200"`
+		Until  *time.Time `json:"until"`
+	}
+	post, ok := moderatedPost(c)
+	if !ok {
+		return
+	}
+	if err := c.BindJSON(&form); err != nil {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, a closing reason is needed.")
+		return
+	}
+	if form.Until != nil && form.Until.Before(time.Now()) {
+		jsonErr(c, http.StatusBadRequest, "Invalid reopening time, it must be in the future.")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	if acl.LoadedACL.User(usr.Id).CanClosePost(post.Category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	err := threads.Close(deps.Container, post, form.Reason, form.Until)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	events.In <- events.RawEmit("feed", "action", map[string]interface{}{
+		"fire":   "closed-post",
+		"id":     post.Id.Hex(),
+		"reason": form.Reason,
+		"until":  form.Until,
+	})
+	sign := signs(c)
+	sign.Reason = form.Reason
+	events.In <- events.UpdatePost(sign, post.Id, "close")
+	c.JSON(http.StatusOK, gin.H{"status": "okay"})
+}
+
+// ReopenPost closed before.
+func ReopenPost(c *gin.Context) {
+	post, ok := moderatedPost(c)
+	if !ok {
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	if acl.LoadedACL.User(usr.Id).CanClosePost(post.Category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	err := threads.Reopen(deps.Container, post)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	events.In <- events.RawEmit("feed", "action", map[string]interface{}{
+		"fire": "reopened-post",
+		"id":   post.Id.Hex(),
+	})
+	events.In <- events.UpdatePost(signs(c), post.Id, "reopen")
+	c.JSON(http.StatusOK, gin.H{"status": "okay"})
+}
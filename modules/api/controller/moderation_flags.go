@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tryanzu/core/board/comments"
+	"github.com/tryanzu/core/board/flags"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/threads"
+	"github.com/tryanzu/core/core/common"
+	"github.com/tryanzu/core/core/config"
+	"github.com/tryanzu/core/core/events"
+	"github.com/tryanzu/core/core/user"
+	"github.com/tryanzu/core/deps"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type resolveFlagsForm struct {
+	Action string `json:"action" binding:"required,eq=accept|eq=reject|eq=escalate"`
+	Note   string `json:"note" binding:"max=255"`
+	Delete bool   `json:"delete"`
+	Lock   bool   `json:"lock"`
+	Ban    string `json:"ban"`
+}
+
+// ModerationFlags queue of flagged posts and comments, their flags grouped
+// and the flagged content inline.
+func ModerationFlags(c *gin.Context) {
+	f := flags.Filter{
+		Status:    c.Query("status"),
+		Reason:    c.Query("reason"),
+		RelatedTo: c.Query("related"),
+		Limit:     20,
+	}
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 && n <= 100 {
+		f.Limit = n
+	}
+	if n, err := strconv.Atoi(c.Query("offset")); err == nil && n > 0 {
+		f.Offset = n
+	}
+	if id := c.Query("category"); bson.IsObjectIdHex(id) {
+		category := bson.ObjectIdHex(id)
+		f.Category = &category
+	}
+	list, err := flags.FindTargets(deps.Container, f)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	plist, err := posts.FindList(deps.Container, common.WithinID(list.IDs("post")))
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	clist, err := comments.FindList(deps.Container, common.WithinID(list.IDs("comment")))
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	pmap := plist.Map()
+	cmap := clist.Map()
+	for i, t := range list {
+		if post, exists := pmap[t.RelatedID]; exists && t.RelatedTo == "post" {
+			list[i].Content = post
+		}
+		if comment, exists := cmap[t.RelatedID]; exists && t.RelatedTo == "comment" {
+			list[i].Content = comment
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"list": list})
+}
+
+// ResolveFlags open over a post or comment. Accepting them may delete the
+// content, lock its post and ban its author along.
+func ResolveFlags(c *gin.Context) {
+	var form resolveFlagsForm
+	related := c.Param("related")
+	id := c.Param("id")
+	if (related != "post" && related != "comment") || bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	if err := c.BindJSON(&form); err != nil {
+		jsonBindErr(c, http.StatusBadRequest, "Invalid resolve request, check parameters", err)
+		return
+	}
+	if (form.Delete || form.Lock || len(form.Ban) > 0) && form.Action != "accept" {
+		jsonErr(c, http.StatusBadRequest, "Actions can only be taken when accepting flags.")
+		return
+	}
+	if form.Lock && len(form.Note) == 0 {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, a note is needed to lock the post.")
+		return
+	}
+	if _, exists := config.C.Rules().BanReasons[form.Ban]; len(form.Ban) > 0 && !exists {
+		jsonErr(c, http.StatusBadRequest, "Invalid ban category")
+		return
+	}
+	relatedID := bson.ObjectIdHex(id)
+	list, err := flags.FindTarget(deps.Container, related, relatedID)
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Flagged content and the post it lives in.
+	var (
+		comment comments.Comment
+		author  bson.ObjectId
+		postID  = relatedID
+	)
+	if related == "comment" {
+		comment, err = comments.FindId(deps.Container, relatedID)
+		if err != nil {
+			jsonErr(c, http.StatusNotFound, "Couldnt find the comment")
+			return
+		}
+		postID, author = comment.RelatedPost(), comment.UserId
+	}
+	post, err := posts.FindId(deps.Container, postID)
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	if related == "post" {
+		author = post.UserId
+	}
+	acl := perms(c)
+	if acl.CanModeratePost(post.Category) == false ||
+		(form.Lock && acl.CanClosePost(post.Category) == false) ||
+		(len(form.Ban) > 0 && acl.Can("users:admin") == false) {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+
+	sign := signs(c)
+	sign.Reason = form.Note
+	actions := []string{}
+	// Content deleted in the meantime stays as it was deleted.
+	deleted := post.Deleted.IsZero() == false
+	if related == "comment" {
+		deleted = comment.Deleted != nil
+	}
+	if form.Delete && !deleted {
+		if related == "post" {
+			err = posts.Delete(deps.Container, post, sign.UserID)
+		} else {
+			err = comments.Delete(deps.Container, comment, sign.UserID)
+		}
+		if err != nil {
+			jsonErr(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if related == "post" {
+			events.In <- events.DeletePost(sign, post.Id)
+		} else {
+			events.In <- events.DeleteComment(sign, post.Id, comment.Id)
+		}
+		actions = append(actions, "delete")
+	}
+	if form.Lock && post.Lock == false {
+		err = threads.Close(deps.Container, post, form.Note, nil)
+		if err != nil {
+			jsonErr(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		events.In <- events.RawEmit("feed", "action", map[string]interface{}{
+			"fire":   "closed-post",
+			"id":     post.Id.Hex(),
+			"reason": form.Note,
+		})
+		events.In <- events.UpdatePost(sign, post.Id, "close")
+		actions = append(actions, "lock")
+	}
+	if len(form.Ban) > 0 {
+		_, err = user.UpsertBan(deps.Container, user.Ban{
+			UserID:    author,
+			RelatedID: &relatedID,
+			RelatedTo: related,
+			Content:   form.Note,
+			Reason:    form.Ban,
+		})
+		if err != nil {
+			jsonErr(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		actions = append(actions, "ban")
+	}
+
+	status := flags.Statuses[form.Action]
+	list, err = flags.Resolve(deps.Container, list, status, form.Note, actions, sign.UserID)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ids := make([]bson.ObjectId, len(list))
+	for i, f := range list {
+		ids[i] = f.ID
+	}
+	events.In <- events.ResolveFlags(sign, related, relatedID, ids, string(status))
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "flags": list, "actions": actions})
+}
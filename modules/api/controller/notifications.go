@@ -43,6 +43,12 @@ func Notifications(c *gin.Context) {
 		return
 	}
 
+	err = notify.MarkSeen(deps.Container, usr.Id)
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+
 	if len(batch) == 0 {
 		c.JSON(200, make([]string, 0))
 		return
@@ -53,7 +53,14 @@ func PostPage(c *gin.Context) {
 		return
 	}
 
-	if post.Slug != c.Param("slug") {
+	// Merged posts live on in the post they were merged into.
+	if post.MergedInto != nil {
+		if into, err := posts.FindId(deps.Container, *post.MergedInto); err == nil {
+			post = into
+		}
+	}
+
+	if post.Slug != c.Param("slug") || post.Id.Hex() != c.Param("id") {
 		c.Redirect(301, c.MustGet("siteUrl").(string)+"/p/"+post.Slug+"/"+post.Id.Hex())
 		return
 	}
@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tryanzu/core/board/polls"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/votes"
+	"github.com/tryanzu/core/core/events"
+	"github.com/tryanzu/core/core/user"
+	"github.com/tryanzu/core/deps"
+	"github.com/tryanzu/core/modules/acl"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type pollForm struct {
+	Question    string     `json:"question" binding:"required,max=255"`
+	Options     []string   `json:"options" binding:"required"`
+	Multiple    bool       `json:"multiple"`
+	Anonymous   bool       `json:"anonymous"`
+	HideResults bool       `json:"hide_results"`
+	ClosesAt    *time.Time `json:"closes_at"`
+}
+
+// NewPoll attaches a poll to a post.
+func NewPoll(c *gin.Context) {
+	var form pollForm
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	if err := c.BindJSON(&form); err != nil {
+		jsonErr(c, http.StatusBadRequest, "Invalid poll request, check parameters")
+		return
+	}
+	post, err := posts.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	if post.UserId != usr.Id && acl.LoadedACL.User(usr.Id).CanModeratePost(post.Category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	poll, err := polls.CreatePoll(deps.Container, polls.Poll{
+		PostID:      post.Id,
+		UserID:      usr.Id,
+		Question:    form.Question,
+		Multiple:    form.Multiple,
+		Anonymous:   form.Anonymous,
+		HideResults: form.HideResults,
+		ClosesAt:    form.ClosesAt,
+	}, form.Options)
+	if err == polls.InvalidPoll || err == polls.PollExists {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	events.In <- events.RawEmit("post."+post.Id.Hex(), "poll", map[string]interface{}{
+		"fire": "poll-created",
+		"id":   poll.ID.Hex(),
+	})
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "poll": poll})
+}
+
+// PostPoll with its results as seen by current user.
+func PostPoll(c *gin.Context) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	if _, err := posts.FindId(deps.Container, bson.ObjectIdHex(id)); err != nil {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	poll, err := polls.FindByPost(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	var viewer bson.ObjectId
+	if uid, exists := c.Get("userID"); exists {
+		viewer = uid.(bson.ObjectId)
+	}
+	results, err := polls.FindResults(deps.Container, poll, viewer)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"poll": poll, "results": results})
+}
+
+// PollVote toggles a vote for a poll option.
+func PollVote(c *gin.Context) {
+	var form struct {
+		Option string `json:"option" binding:"required"`
+	}
+	poll, ok := pollParam(c)
+	if ok == false {
+		return
+	}
+	if err := c.BindJSON(&form); err != nil {
+		jsonErr(c, http.StatusBadRequest, "Invalid vote request, check parameters")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	if usr.Validated == false {
+		jsonErr(c, http.StatusForbidden, "Only validated users can vote")
+		return
+	}
+	err := polls.Vote(deps.Container, poll, usr.Id, form.Option)
+	if _, invalid := err.(*votes.NotAllowed); invalid || err == polls.PollClosed {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	results, err := polls.FindResults(deps.Container, poll, usr.Id)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	broadcastPoll(poll)
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "results": results})
+}
+
+// ClosePoll before its close date, only its post author or moderators can.
+func ClosePoll(c *gin.Context) {
+	poll, ok := pollParam(c)
+	if ok == false {
+		return
+	}
+	post, err := posts.FindId(deps.Container, poll.PostID)
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	if post.UserId != usr.Id && acl.LoadedACL.User(usr.Id).CanModeratePost(post.Category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	poll, err = polls.Close(deps.Container, poll)
+	if err == polls.PollClosed {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	broadcastPoll(poll)
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "poll": poll})
+}
+
+func pollParam(c *gin.Context) (poll polls.Poll, ok bool) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid poll id")
+		return
+	}
+	poll, err := polls.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	return poll, true
+}
+
+// broadcastPoll results as seen by anyone, so hidden results stay hidden.
+func broadcastPoll(poll polls.Poll) {
+	results, err := polls.FindResults(deps.Container, poll, bson.ObjectId(""))
+	if err != nil {
+		return
+	}
+	events.In <- events.RawEmit("post."+poll.PostID.Hex(), "poll", map[string]interface{}{
+		"fire":    "poll-results",
+		"id":      poll.ID.Hex(),
+		"results": results,
+	})
+}
@@ -2,12 +2,20 @@ package controller
 
 import (
 	"errors"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tryanzu/core/board/comments"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/search"
+	"github.com/tryanzu/core/core/config"
 	"github.com/tryanzu/core/core/events"
 	"github.com/tryanzu/core/core/user"
 	"github.com/tryanzu/core/deps"
+	"github.com/tryanzu/core/modules/acl"
+	"github.com/tryanzu/core/modules/helpers"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -52,3 +60,175 @@ func UpdatePost(c *gin.Context) {
 	events.In <- events.PostComment(comment.Id)
 	c.JSON(200, comment)
 }
+
+// PostRevisions lists the edit history of a post.
+func PostRevisions(c *gin.Context) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	post, err := posts.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil || post.Deleted.IsZero() == false {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	if postHistoryVisible(c, post) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to see this post history")
+		return
+	}
+	list, err := posts.FindRevisions(deps.Container, post.Id)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": list})
+}
+
+// PostRevisionsDiff between any two revisions of a post.
+func PostRevisionsDiff(c *gin.Context) {
+	id, from, to := c.Param("id"), c.Query("from"), c.Query("to")
+	if bson.IsObjectIdHex(id) == false || bson.IsObjectIdHex(from) == false || bson.IsObjectIdHex(to) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	post, err := posts.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil || post.Deleted.IsZero() == false {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	if postHistoryVisible(c, post) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to see this post history")
+		return
+	}
+	a, err := posts.FindRevision(deps.Container, post.Id, bson.ObjectIdHex(from))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	b, err := posts.FindRevision(deps.Container, post.Id, bson.ObjectIdHex(to))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	diff := helpers.UnifiedDiff(a.Text(), b.Text(), a.ID.Hex(), b.ID.Hex())
+	c.JSON(http.StatusOK, gin.H{"from": a, "to": b, "diff": diff})
+}
+
+// postHistoryVisible to the signed user. Unless the site makes edit history
+// public, only the author and moderators can see earlier contents.
+func postHistoryVisible(c *gin.Context, post posts.Post) bool {
+	if config.C.Copy().Site.PublicEditHistory {
+		return true
+	}
+	uid, exists := c.Get("userID")
+	if !exists {
+		return false
+	}
+	id := uid.(bson.ObjectId)
+	return post.UserId == id || acl.LoadedACL.User(id).CanModeratePost(post.Category)
+}
+
+// RollbackPost to a previous revision. The rollback is tracked as a new revision.
+func RollbackPost(c *gin.Context) {
+	id, rid := c.Param("id"), c.Param("rid")
+	if bson.IsObjectIdHex(id) == false || bson.IsObjectIdHex(rid) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	post, err := posts.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	if acl.LoadedACL.User(usr.Id).CanModeratePost(post.Category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	target, err := posts.FindRevision(deps.Container, post.Id, bson.ObjectIdHex(rid))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	slug := post.Slug
+	if target.Title != post.Title {
+		slug = helpers.StrSlug(target.Title)
+		if n, _ := deps.Container.Mgo().C("posts").Find(bson.M{"slug": slug, "_id": bson.M{"$ne": post.Id}}).Count(); n > 0 {
+			slug = helpers.StrSlugRandom(target.Title)
+		}
+	}
+	err = deps.Container.Mgo().C("posts").UpdateId(post.Id, bson.M{"$set": bson.M{
+		"title":      target.Title,
+		"slug":       slug,
+		"content":    target.Content,
+		"updated_at": time.Now(),
+	}})
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sign := signs(c)
+	revision, err := posts.TrackRevision(deps.Container, post, posts.Revision{
+		UserID:     usr.Id,
+		Title:      target.Title,
+		Content:    target.Content,
+		Reason:     sign.Reason,
+		Action:     posts.RevisionRollback,
+		RollbackOf: &target.ID,
+	})
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if target.Title != post.Title {
+		events.In <- events.RawEmit("feed", "action", map[string]interface{}{
+			"fire":  "changed-title",
+			"id":    post.Id.Hex(),
+			"title": target.Title,
+			"slug":  slug,
+		})
+	}
+	events.In <- events.RawEmit("post", post.Id.Hex(), map[string]interface{}{
+		"fire": "updated",
+	})
+	events.In <- events.UpdatePost(sign, post.Id, posts.RevisionRollback)
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "revision": revision})
+}
+
+// RelatedPosts suggests discussions similar to a post.
+func RelatedPosts(c *gin.Context) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	post, err := posts.FindId(deps.Container, bson.ObjectIdHex(id))
+	if err != nil || post.Deleted.IsZero() == false {
+		jsonErr(c, http.StatusNotFound, "Couldnt find the post")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"list": search.RelatedPosts(post, 5)})
+}
+
+// SimilarPosts to a title, shown as possible duplicates while writing a post.
+func SimilarPosts(c *gin.Context) {
+	title := strings.TrimSpace(c.Query("title"))
+	if len(title) == 0 || len(title) > 200 {
+		jsonErr(c, http.StatusBadRequest, "Invalid title to match")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"list": search.SimilarPosts(title, 5)})
+}
+
+// SimilarPostsOr serves /posts/similar, which shares its path with /posts/:id.
+func SimilarPostsOr(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Param("id") == "similar" {
+			SimilarPosts(c)
+			return
+		}
+		handler(c)
+	}
+}
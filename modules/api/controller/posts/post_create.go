@@ -3,7 +3,10 @@ package posts
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"github.com/tryanzu/core/board/drafts"
 	"github.com/tryanzu/core/board/legacy/model"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/core/content"
 	"github.com/tryanzu/core/core/events"
 	"github.com/tryanzu/core/core/user"
 	"github.com/tryanzu/core/deps"
@@ -68,6 +71,16 @@ func (this API) Create(c *gin.Context) {
 		return
 	}
 
+	// Scheduled posts stay hidden until the publishing job picks them up.
+	var publishAt *time.Time
+	if form.PublishAt != nil && form.PublishAt.After(time.Now()) {
+		if form.PublishAt.After(time.Now().AddDate(0, 0, posts.MaxScheduleDays)) {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Posts can't be scheduled that far ahead."})
+			return
+		}
+		publishAt = form.PublishAt
+	}
+
 	comments := model.Comments{
 		Count: 0,
 		Set:   make([]model.Comment, 0),
@@ -79,10 +92,10 @@ func (this API) Create(c *gin.Context) {
 		Rating: 0,
 	}
 
-	content := html.EscapeString(form.Content)
+	body := html.EscapeString(form.Content)
 
 	var assets []string
-	assets = assetURL.FindAllString(content, -1)
+	assets = assetURL.FindAllString(body, -1)
 
 	// Empty participants list - only author included
 	users := []bson.ObjectId{uid}
@@ -91,15 +104,46 @@ func (this API) Create(c *gin.Context) {
 		title = helpers.Truncate(title, 72) + "..."
 	}
 
+	// Run site content filters over title & content.
+	id := bson.NewObjectId()
+	held := false
+	for _, field := range []*string{&title, &body} {
+		filtered, err := content.Filter(deps.Container, content.Text{
+			ID:      id,
+			UserID:  uid,
+			Related: "post",
+			Content: *field,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error()})
+			return
+		}
+		text := filtered.(content.Text)
+		held = held || text.Held
+		*field = text.Content
+	}
+	expanded, err := content.ExpandEmojis(deps.Container, content.Text{
+		ID:       id,
+		UserID:   uid,
+		Category: category.Id,
+		Related:  "post",
+		Content:  body,
+	})
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	body = expanded.GetContent()
+
 	slug := helpers.StrSlug(title)
 	if c, _ := deps.Container.Mgo().C("posts").Find(bson.M{"slug": slug}).Count(); c > 0 {
 		slug = helpers.StrSlugRandom(title)
 	}
 
 	publish := model.Post{
-		Id:         bson.NewObjectId(),
+		Id:         id,
 		Title:      title,
-		Content:    content,
+		Content:    body,
 		Type:       "category-post",
 		Slug:       slug,
 		Comments:   comments,
@@ -112,6 +156,10 @@ func (this API) Create(c *gin.Context) {
 		Lock:       form.Lock,
 		Created:    time.Now(),
 		Updated:    time.Now(),
+		PublishAt:  publishAt,
+	}
+	if publishAt != nil {
+		publish.Created = *publishAt
 	}
 
 	u, err := user.FindId(deps.Container, uid)
@@ -124,13 +172,25 @@ func (this API) Create(c *gin.Context) {
 		publish.Deleted = time.Now()
 	}
 
+	// Posts held by content filters stay hidden until reviewed.
+	if held {
+		publish.Deleted = time.Now()
+	}
+
 	err = deps.Container.Mgo().C("posts").Insert(&publish)
 	if err != nil {
 		panic(err)
 	}
 
 	// Notify events pool immediately after performing save.
-	events.In <- events.PostNew(publish.Id)
+	if publishAt == nil {
+		events.In <- events.PostNew(publish.Id)
+	}
+
+	// A published draft is no longer needed.
+	if bson.IsObjectIdHex(form.DraftID) {
+		drafts.Delete(deps.Container, uid, bson.ObjectIdHex(form.DraftID))
+	}
 
 	for _, asset := range assets {
 
@@ -139,5 +199,5 @@ func (this API) Create(c *gin.Context) {
 	}
 
 	// Finished creating the post
-	c.JSON(200, gin.H{"status": "okay", "code": 200, "post": gin.H{"id": publish.Id, "slug": slug}})
+	c.JSON(200, gin.H{"status": "okay", "code": 200, "post": gin.H{"id": publish.Id, "slug": slug, "publish_at": publishAt}})
 }
@@ -2,6 +2,7 @@ package posts
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/tryanzu/core/board/relations"
 	"github.com/tryanzu/core/core/content"
 	"github.com/tryanzu/core/core/events"
 	"github.com/tryanzu/core/deps"
@@ -36,6 +37,18 @@ func (this API) Get(c *gin.Context) {
 		post, err = this.Feed.Post(bson.M{"slug": id})
 	}
 	if err != nil {
+		// Merged posts redirect into the post they were merged into.
+		var merged struct {
+			Into bson.ObjectId `bson:"merged_into"`
+		}
+		criteria := bson.M{"slug": id, "merged_into": bson.M{"$exists": true}}
+		if kind == "id" {
+			criteria = bson.M{"_id": bson.ObjectIdHex(id), "merged_into": bson.M{"$exists": true}}
+		}
+		if deps.Container.Mgo().C("posts").Find(criteria).One(&merged) == nil {
+			c.Redirect(301, "/v1/posts/"+merged.Into.Hex())
+			return
+		}
 		c.JSON(404, gin.H{"message": "Couldnt found post.", "status": "error"})
 		return
 	}
@@ -54,6 +67,10 @@ func (this API) Get(c *gin.Context) {
 	post.LoadUsersHashtables()
 	data := post.Data()
 	data.Comments.Total = this.Feed.TrueCommentCount(data.Id)
+	if sid, exists := c.Get("userID"); exists {
+		_, err := relations.FindOne(deps.Container, sid.(bson.ObjectId), relations.Follow, "post", data.Id)
+		data.Following = err == nil
+	}
 
 	c.JSON(200, data)
 }
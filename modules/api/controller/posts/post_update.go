@@ -3,6 +3,7 @@ package posts
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/tryanzu/core/board/legacy/model"
+	posts "github.com/tryanzu/core/board/posts"
 	"github.com/tryanzu/core/core/content"
 	"github.com/tryanzu/core/core/events"
 	"github.com/tryanzu/core/deps"
@@ -62,6 +63,11 @@ func (this API) Update(c *gin.Context) {
 		return
 	}
 
+	if post.Archived != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Archived posts are read-only."})
+		return
+	}
+
 	if post.Category != category.Id && user.CanWrite(category.Permissions.Write) == false {
 		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Not enough permissions to write this category."})
 		return
@@ -77,6 +83,26 @@ func (this API) Update(c *gin.Context) {
 		return
 	}
 
+	// Keep current state around to track the revision history.
+	before, err := posts.FindId(deps.Container, post.Id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"message": "Couldnt find the post", "status": "error"})
+		return
+	}
+
+	filtered, err := content.Filter(deps.Container, content.Text{
+		ID:      post.Id,
+		UserID:  uid,
+		Related: "post",
+		Content: form.Title,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+	title := filtered.(content.Text)
+	form.Title = title.Content
+
 	slug := post.Slug
 	if form.Title != post.Title {
 		slug := helpers.StrSlug(form.Title)
@@ -95,6 +121,10 @@ func (this API) Update(c *gin.Context) {
 	post.Content = html.EscapeString(form.Content)
 	// Pre-process comment content.
 	processed, err := content.Preprocess(deps.Container, post)
+	if _, blocked := err.(content.Blocked); blocked {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
 	if err != nil {
 		return
 	}
@@ -109,6 +139,11 @@ func (this API) Update(c *gin.Context) {
 			"updated_at": time.Now(),
 		},
 	}
+	if title.Held || post.Deleted.IsZero() == false {
+		set := query["$set"].(bson.M)
+		set["deleted_at"] = time.Now()
+		query["$set"] = set
+	}
 	unset := bson.M{}
 	if form.Pinned == true {
 		// Update the set directive by creating a copy of it and using type assertion
@@ -140,7 +175,8 @@ func (this API) Update(c *gin.Context) {
 				"fire": "locked",
 			})
 		}
-	} else {
+	} else if post.LockReason == "" {
+		// Closed posts are only reopened through moderation.
 		unset["lock"] = ""
 		if post.Lock == true {
 			events.In <- events.RawEmit("post", post.Id.Hex(), map[string]interface{}{
@@ -164,6 +200,25 @@ func (this API) Update(c *gin.Context) {
 		panic(err)
 	}
 
+	if form.Title != before.Title || post.Content != before.Content {
+		_, err = posts.TrackRevision(deps.Container, before, posts.Revision{
+			UserID:  uid,
+			Title:   form.Title,
+			Content: post.Content,
+			Reason:  form.Reason,
+			Action:  posts.RevisionUpdate,
+		})
+		if err != nil {
+			c.AbortWithError(500, err)
+			return
+		}
+		sign := signs(c)
+		if len(form.Reason) > 0 {
+			sign.Reason = form.Reason
+		}
+		events.In <- events.UpdatePost(sign, post.Id, posts.RevisionUpdate)
+	}
+
 	events.In <- events.RawEmit("post", post.Id.Hex(), map[string]interface{}{
 		"fire": "updated",
 	})
@@ -8,9 +8,12 @@ import (
 	"github.com/tryanzu/core/core/events"
 	"github.com/tryanzu/core/core/user"
 	"github.com/tryanzu/core/deps"
+	"github.com/tryanzu/core/modules/gaming"
 	"gopkg.in/mgo.v2/bson"
 
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type upsertReactionBody struct {
@@ -44,14 +47,18 @@ func UpsertReaction(c *gin.Context) {
 		return
 	}
 
+	archived := false
 	switch c.Params.ByName("type") {
 	case "post":
 		if post, err := post.FindId(deps.Container, id); err == nil {
 			votable = post
+			archived = post.Archived != nil
 		}
 	case "comment":
 		if comment, err := comments.FindId(deps.Container, id); err == nil {
 			votable = comment
+			p, err := post.FindId(deps.Container, comment.RelatedPost())
+			archived = err == nil && p.Archived != nil
 		}
 	default:
 		jsonErr(c, http.StatusBadRequest, "invalid type")
@@ -62,9 +69,29 @@ func UpsertReaction(c *gin.Context) {
 		jsonErr(c, http.StatusNotFound, "invalid id")
 		return
 	}
+	if archived {
+		jsonErr(c, http.StatusForbidden, "Archived posts are read-only.")
+		return
+	}
+
+	// Every toggle counts against the daily quota of the user level,
+	// refused ones are given back.
+	now := time.Now()
+	quota, err := votes.SpendQuota(deps.Container, usr.Id, gaming.ReactionQuota(deps.Container, usr.Gaming.Level), now)
+	if err == votes.QuotaExceeded {
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"status": "error", "message": err.Error(), "quota": quota})
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
 
 	vote, status, err := votes.UpsertVote(deps.Container, votable, usr.Id, body.Type)
 	if err != nil {
+		if err := votes.RefundQuota(deps.Container, usr.Id, now); err != nil {
+			log.Errorf("refunding reactions quota failed	user=%v err=%v", usr.Id.Hex(), err)
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error()})
 		return
 	}
@@ -79,3 +106,77 @@ func UpsertReaction(c *gin.Context) {
 
 	c.JSON(http.StatusOK, status)
 }
+
+// UserReactions timeline, the ones received by the user or given with ?direction=given.
+func UserReactions(c *gin.Context) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+	given := c.Query("direction") == "given"
+	timeline, err := votes.FindTimeline(deps.Container, bson.ObjectIdHex(id), given, c.DefaultQuery("period", "month"))
+	if err == votes.InvalidPeriod {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, timeline)
+}
+
+// UserReactionsOr serves /users/:id/reactions, which shares its path with /users/:id/:kind.
+func UserReactionsOr(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Param("kind") == "reactions" {
+			UserReactions(c)
+			return
+		}
+		handler(c)
+	}
+}
+
+// TopReacted posts or comments over a period, optionally within a category.
+func TopReacted(c *gin.Context) {
+	kind := c.DefaultQuery("type", "post")
+	if kind != "post" && kind != "comment" {
+		jsonErr(c, http.StatusBadRequest, "invalid type")
+		return
+	}
+	limit := 10
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 && n <= 50 {
+		limit = n
+	}
+	var category *bson.ObjectId
+	if id := c.Query("category"); bson.IsObjectIdHex(id) {
+		cid := bson.ObjectIdHex(id)
+		category = &cid
+	}
+	list, err := votes.FindTopReacted(deps.Container, kind, category, c.DefaultQuery("period", "week"), limit)
+	if err == votes.InvalidPeriod {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"list": list})
+}
+
+// PostReactions mix over the post and its comments.
+func PostReactions(c *gin.Context) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid post id")
+		return
+	}
+	mix, err := votes.FindMix(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, mix)
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	posts "github.com/tryanzu/core/board/posts"
+	"github.com/tryanzu/core/board/relations"
+	"github.com/tryanzu/core/core/user"
+	"github.com/tryanzu/core/deps"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Relations of signed user by kind: follow, mute or block.
+func Relations(c *gin.Context) {
+	usr := c.MustGet("user").(user.User)
+	list, err := relations.FindList(deps.Container, usr.Id, c.Param("kind"))
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"relations": list})
+}
+
+// Relate signed user with a user, post or category.
+func Relate(c *gin.Context) {
+	kind, related, id := c.Param("kind"), c.Param("related"), c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	relation, err := relations.Relate(deps.Container, usr.Id, kind, related, bson.ObjectIdHex(id))
+	if err == relations.InvalidRelation {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "relation": relation})
+}
+
+// Unrelate signed user from a user, post or category.
+func Unrelate(c *gin.Context) {
+	kind, related, id := c.Param("kind"), c.Param("related"), c.Param("id")
+	if bson.IsObjectIdHex(id) == false || relations.Valid(kind, related) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	usr := c.MustGet("user").(user.User)
+	err := relations.Unrelate(deps.Container, usr.Id, kind, related, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay"})
+}
+
+// FollowedThreads of signed user, last active first.
+func FollowedThreads(c *gin.Context) {
+	var (
+		limit  = 10
+		offset = 0
+	)
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 && n <= 50 {
+		limit = n
+	}
+	if n, err := strconv.Atoi(c.Query("offset")); err == nil && n > 0 {
+		offset = n
+	}
+	usr := c.MustGet("user").(user.User)
+	ids, err := relations.FindRelated(deps.Container, usr.Id, relations.Follow, "post")
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	list, err := posts.FindLatest(deps.Container, ids, offset, limit)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	for n := range list {
+		list[n].Following = true
+	}
+	c.JSON(http.StatusOK, gin.H{"posts": list, "offset": offset, "limit": limit})
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tryanzu/core/board/trash"
+	"github.com/tryanzu/core/core/events"
+	"github.com/tryanzu/core/deps"
+	"github.com/tryanzu/core/modules/acl"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Trash list of deleted posts and comments paginated by cursor. Dates are
+// expected in RFC3339 format. Category moderators list their category only.
+func Trash(c *gin.Context) {
+	f := trash.Filter{
+		Related: c.Query("related"),
+		Limit:   20,
+	}
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 && n <= 100 {
+		f.Limit = n
+	}
+	if id := c.Query("category"); bson.IsObjectIdHex(id) {
+		category := bson.ObjectIdHex(id)
+		f.Category = &category
+	}
+	perms := acl.LoadedACL.User(c.MustGet("userID").(bson.ObjectId))
+	if (f.Category == nil && perms.Can("edit-board-posts") == false) || (f.Category != nil && perms.CanModeratePost(*f.Category) == false) {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	if id := c.Query("deleted_by"); bson.IsObjectIdHex(id) {
+		by := bson.ObjectIdHex(id)
+		f.DeletedBy = &by
+	}
+	if id := c.Query("before"); bson.IsObjectIdHex(id) {
+		before := bson.ObjectIdHex(id)
+		f.Before = &before
+	}
+	if t, err := time.Parse(time.RFC3339, c.Query("from")); err == nil {
+		f.From = &t
+	}
+	if t, err := time.Parse(time.RFC3339, c.Query("to")); err == nil {
+		f.To = &t
+	}
+	list, next, err := trash.FindList(deps.Container, f)
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"list": list, "next": next})
+}
+
+// RestoreTrash item, bringing a post or comment back.
+func RestoreTrash(c *gin.Context) {
+	id := c.Param("id")
+	if bson.IsObjectIdHex(id) == false {
+		jsonErr(c, http.StatusBadRequest, "Invalid request, no valid params.")
+		return
+	}
+	item, err := trash.FindOne(deps.Container, bson.ObjectIdHex(id))
+	if err != nil {
+		jsonErr(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if acl.LoadedACL.User(c.MustGet("userID").(bson.ObjectId)).CanModeratePost(item.Category) == false {
+		jsonErr(c, http.StatusForbidden, "Not allowed to perform this operation")
+		return
+	}
+	err = trash.Restore(deps.Container, item)
+	if err == trash.InvalidRestore {
+		jsonErr(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		jsonErr(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if item.Related == "post" {
+		events.In <- events.RestorePost(signs(c), item.RelatedID)
+	} else {
+		events.In <- events.RestoreComment(signs(c), item.PostID, item.RelatedID)
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "okay", "item": item})
+}
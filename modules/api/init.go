@@ -117,14 +117,23 @@ func (module *Module) Run(bindTo string) {
 
 	// Post routes
 	v1.GET("/feed", module.Posts.FeedGet)
-	v1.GET("/posts/:id", module.PostsFactory.Get)
+	v1.GET("/posts/:id", controller.SimilarPostsOr(module.PostsFactory.Get))
+	v1.GET("/posts/:id/related", controller.RelatedPosts)
+	v1.GET("/posts/:id/reactions", controller.PostReactions)
+	v1.GET("/posts/:id/revisions", controller.PostRevisions)
+	v1.GET("/posts/:id/revisions/diff", controller.PostRevisionsDiff)
+	v1.GET("/posts/:id/poll", controller.PostPoll)
+	v1.GET("/posts/:id/bounty", controller.PostBounty)
 	v1.GET("/comments/:post_id", controller.Comments)
+	v1.GET("/comments/:post_id/revisions", controller.CommentRevisions)
+	v1.GET("/comments/:post_id/context/:comment_id", controller.CommentContext)
 
 	// User routes
 	v1.GET("/search/users/:name", controller.SearchUsers)
 	v1.POST("/user", module.Users.UserRegisterAction)
 	v1.GET("/users/:id", module.Users.UserGetOne)
-	v1.GET("/users/:id/:kind", module.Users.UserGetActivity)
+	v1.GET("/users/:id/:kind", controller.UserReactionsOr(module.Users.UserGetActivity))
+	v1.GET("/reactions/top", controller.TopReacted)
 	v1.GET("/user/search", module.Users.UserAutocompleteGet)
 	v1.POST("/auth/get-token", module.Users.UserGetJwtToken)
 	v1.GET("/auth/lost-password", module.UsersFactory.RequestPasswordRecovery)
@@ -133,11 +142,17 @@ func (module *Module) Run(bindTo string) {
 	// Categories routes
 	v1.GET("/category", controller.Categories)
 
+	// Emoji routes
+	v1.GET("/emojis", controller.Emojis)
+
 	authorized := v1.Group("")
 	authorized.Use(module.Middlewares.NeedAuthorization())
 
 	authorized.PUT("/config", chttp.UserMiddleware(), chttp.Can("board-config"), controller.UpdateConfig)
 	authorized.GET("/notifications", chttp.UserMiddleware(), controller.Notifications)
+	authorized.POST("/emojis", chttp.UserMiddleware(), chttp.Can("board-config"), controller.UploadEmoji)
+	authorized.PUT("/emojis/:id", chttp.UserMiddleware(), chttp.Can("board-config"), controller.UpdateEmoji)
+	authorized.DELETE("/emojis/:id", chttp.UserMiddleware(), chttp.Can("board-config"), controller.DeleteEmoji)
 
 	// Auth routes
 	authorized.GET("/auth/resend-confirmation", module.UsersFactory.ResendConfirmation)
@@ -146,6 +161,8 @@ func (module *Module) Run(bindTo string) {
 	authorized.POST("/comments/:id", chttp.UserMiddleware(), chttp.Can("comment"), controller.NewComment)
 	authorized.PUT("/comments/:id", chttp.UserMiddleware(), chttp.Can("comment"), controller.UpdateComment)
 	authorized.DELETE("/comments/:id", chttp.UserMiddleware(), chttp.Can("comment"), controller.DeleteComment)
+	authorized.POST("/comments/:id/accept", chttp.UserMiddleware(), controller.AcceptAnswer)
+	authorized.DELETE("/comments/:id/accept", chttp.UserMiddleware(), controller.UnacceptAnswer)
 
 	// Flag routes
 	authorized.POST("/flags", chttp.UserMiddleware(), controller.NewFlag)
@@ -156,16 +173,56 @@ func (module *Module) Run(bindTo string) {
 	authorized.POST("/post/image", module.Posts.PostUploadAttachment)
 	authorized.PUT("/posts/:id", module.PostsFactory.Update)
 	authorized.DELETE("/posts/:id", module.Posts.PostDelete)
+	authorized.PUT("/posts/:id/schedule", chttp.UserMiddleware(), controller.ReschedulePost)
+	authorized.DELETE("/posts/:id/schedule", chttp.UserMiddleware(), controller.CancelScheduledPost)
+	authorized.POST("/posts/:id/revisions/:rid/rollback", chttp.UserMiddleware(), controller.RollbackPost)
+	authorized.POST("/posts/:id/poll", chttp.UserMiddleware(), controller.NewPoll)
+	authorized.POST("/posts/:id/bounty", chttp.UserMiddleware(), controller.OfferBounty)
+	authorized.POST("/posts/:id/move", chttp.UserMiddleware(), controller.MovePost)
+	authorized.POST("/posts/:id/merge", chttp.UserMiddleware(), controller.MergePost)
+	authorized.POST("/posts/:id/split", chttp.UserMiddleware(), controller.SplitPost)
+	authorized.POST("/posts/:id/close", chttp.UserMiddleware(), controller.ClosePost)
+	authorized.DELETE("/posts/:id/close", chttp.UserMiddleware(), controller.ReopenPost)
+	authorized.GET("/trash", chttp.UserMiddleware(), controller.Trash)
+	authorized.POST("/trash/:id/restore", chttp.UserMiddleware(), controller.RestoreTrash)
+	authorized.GET("/moderation/flags", chttp.UserMiddleware(), chttp.Can("delete-board-posts"), controller.ModerationFlags)
+	authorized.POST("/moderation/flags/:related/:id/resolve", chttp.UserMiddleware(), chttp.Can("delete-board-posts"), controller.ResolveFlags)
+	authorized.POST("/polls/:id/vote", chttp.UserMiddleware(), controller.PollVote)
+	authorized.POST("/polls/:id/close", chttp.UserMiddleware(), controller.ClosePoll)
+
+	// Home feed & relations routes
+	authorized.GET("/feed/home", chttp.UserMiddleware(), module.Posts.FeedHome)
+	authorized.GET("/relations/:kind", chttp.UserMiddleware(), controller.Relations)
+	authorized.PUT("/relations/:kind/:related/:id", chttp.UserMiddleware(), controller.Relate)
+	authorized.DELETE("/relations/:kind/:related/:id", chttp.UserMiddleware(), controller.Unrelate)
+	authorized.POST("/category/subscription/:id", module.Users.UserCategorySubscribe)
+	authorized.DELETE("/category/subscription/:id", module.Users.UserCategoryUnsubscribe)
+	authorized.PUT("/category/:id/policy", chttp.UserMiddleware(), chttp.Can("board-config"), controller.UpdateCategoryPolicy)
+
+	// Bookmark routes
+	authorized.GET("/bookmarks", chttp.UserMiddleware(), controller.Bookmarks)
+	authorized.GET("/bookmarks/collections", chttp.UserMiddleware(), controller.BookmarkCollections)
+	authorized.PUT("/bookmarks/:related/:id", chttp.UserMiddleware(), controller.SaveBookmark)
+	authorized.DELETE("/bookmarks/:related/:id", chttp.UserMiddleware(), controller.RemoveBookmark)
+
+	// Draft routes
+	authorized.GET("/drafts", chttp.UserMiddleware(), controller.Drafts)
+	authorized.POST("/drafts", chttp.UserMiddleware(), controller.SaveDraft)
+	authorized.DELETE("/drafts/:id", chttp.UserMiddleware(), controller.DeleteDraft)
 
 	// User routes
 	authorized.GET("/users", chttp.UserMiddleware(), chttp.Can("users:admin"), controller.Users)
 	authorized.POST("/user/my/avatar", module.Users.UserUpdateProfileAvatar)
 	authorized.GET("/user/my", module.Users.UserGetByToken)
+	authorized.GET("/user/my/following", chttp.UserMiddleware(), controller.FollowedThreads)
 	authorized.PUT("/user/my", module.Users.UserUpdateProfile)
 	authorized.PATCH("/me/:field", module.UsersFactory.Patch)
 	authorized.GET("/reasons/ban", chttp.UserMiddleware(), chttp.Can("users:admin"), controller.BanReasons)
 	authorized.GET("/reasons/flag", chttp.UserMiddleware(), controller.FlagReasons)
 	authorized.POST("/ban", chttp.UserMiddleware(), chttp.Can("users:admin"), controller.Ban)
+	authorized.GET("/reports/votes", chttp.UserMiddleware(), chttp.Can("users:admin"), controller.VoteReports)
+	authorized.POST("/reports/votes/:id/neutralize", chttp.UserMiddleware(), chttp.Can("users:admin"), controller.NeutralizeVoteReport)
+	authorized.POST("/reports/votes/:id/dismiss", chttp.UserMiddleware(), chttp.Can("users:admin"), controller.DismissVoteReport)
 
 	// Votes routes
 	authorized.POST("/react/:type/:id", chttp.UserMiddleware(), controller.UpsertReaction)
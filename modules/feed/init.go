@@ -23,7 +23,7 @@ type FeedModule struct {
 func (feed *FeedModule) Post(where interface{}) (post *Post, err error) {
 	switch where.(type) {
 	case bson.ObjectId, bson.M:
-		var criteria = bson.M{"deleted_at": bson.M{"$exists": false}}
+		var criteria = bson.M{"deleted_at": bson.M{"$exists": false}, "publish_at": bson.M{"$exists": false}}
 
 		switch where.(type) {
 		case bson.ObjectId:
@@ -30,6 +30,9 @@ type Post struct {
 	Following         bool             `bson:"following,omitempty" json:"following,omitempty"`
 	Pinned            bool             `bson:"pinned,omitempty" json:"pinned,omitempty"`
 	Lock              bool             `bson:"lock" json:"lock"`
+	LockReason        string           `bson:"lock_reason,omitempty" json:"lock_reason,omitempty"`
+	LockUntil         *time.Time       `bson:"lock_until,omitempty" json:"lock_until,omitempty"`
+	Archived          *time.Time       `bson:"archived_at,omitempty" json:"archived_at,omitempty"`
 	IsQuestion        bool             `bson:"is_question" json:"is_question"`
 	Solved            bool             `bson:"solved,omitempty" json:"solved,omitempty"`
 	Views             int              `bson:"views,omitempty" json:"views"`
@@ -265,7 +268,14 @@ func (p *Post) UpdateContent(content string) content.Parseable {
 func (p *Post) GetParseableMeta() map[string]interface{} {
 	meta := make(map[string]interface{})
 	meta["id"] = p.Id
-	meta["related"] = "comment"
+	meta["related"] = "post"
 	meta["user_id"] = p.UserId
+	meta["category"] = p.Category
 	return meta
 }
+
+// HoldForReview hides the post until a moderator reviews it.
+func (p *Post) HoldForReview() content.Parseable {
+	p.Deleted = time.Now()
+	return p
+}
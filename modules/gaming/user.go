@@ -1,14 +1,20 @@
 package gaming
 
 import (
+	"errors"
 	"time"
 
 	notify "github.com/tryanzu/core/board/notifications"
+	"github.com/tryanzu/core/core/config"
 	"github.com/tryanzu/core/deps"
 	"github.com/tryanzu/core/modules/user"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
 
+// NotEnoughCoins err.
+var NotEnoughCoins = errors.New("Not enough coins to perform this operation.")
+
 // IncreaseUserSwords for given id.
 func IncreaseUserSwords(d Deps, id bson.ObjectId, swords int) error {
 	return increaseUserAttr(d, id, "gaming.swords", swords)
@@ -19,11 +25,41 @@ func IncreaseUserCoins(d Deps, id bson.ObjectId, coins int) error {
 	return increaseUserAttr(d, id, "gaming.coins", coins)
 }
 
+// SpendUserCoins for given id, only when the user has enough of them.
+func SpendUserCoins(d Deps, id bson.ObjectId, coins int) error {
+	err := d.Mgo().C("users").Update(bson.M{"_id": id, "gaming.coins": bson.M{"$gte": coins}}, bson.M{"$inc": bson.M{"gaming.coins": -coins}})
+	if err == mgo.ErrNotFound {
+		return NotEnoughCoins
+	}
+	return err
+}
+
 // IncreaseUserTribute for given id.
 func IncreaseUserTribute(d Deps, id bson.ObjectId, tribute int) error {
 	return increaseUserAttr(d, id, "gaming.tribute", tribute)
 }
 
+// ReactionQuota of users at given level, zero means no quota.
+func ReactionQuota(d Deps, level int) int {
+	for _, r := range d.GamingConfig().Rules {
+		if r.Level == level {
+			return r.Reactions
+		}
+	}
+	return 0
+}
+
+// Reward user with given gains, negative ones are taken away.
+func Reward(d Deps, id bson.ObjectId, g config.Gains) (err error) {
+	if err = IncreaseUserSwords(d, id, g.Swords); err != nil {
+		return
+	}
+	if err = IncreaseUserCoins(d, id, g.Coins); err != nil {
+		return
+	}
+	return IncreaseUserTribute(d, id, g.Tribute)
+}
+
 func increaseUserAttr(d Deps, id bson.ObjectId, field string, n int) (err error) {
 	if n == 0 {
 		// ignore.
@@ -0,0 +1,148 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte
+	text string
+	a, b int
+}
+
+// UnifiedDiff between two texts using line granularity.
+func UnifiedDiff(from, to, fromName, toName string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+	var changes []int
+	for i, op := range ops {
+		if op.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+	for n := 0; n < len(changes); {
+		start := changes[n] - diffContext
+		if start < 0 {
+			start = 0
+		}
+		last := changes[n]
+		for n++; n < len(changes) && changes[n]-last <= 2*diffContext; n++ {
+			last = changes[n]
+		}
+		end := last + diffContext + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+		writeHunk(&out, ops[start:end])
+	}
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, hunk []diffOp) {
+	aStart, bStart := hunk[0].a, hunk[0].b
+	aCount, bCount := 0, 0
+	for _, op := range hunk {
+		if op.kind != '+' {
+			aCount++
+		}
+		if op.kind != '-' {
+			bCount++
+		}
+	}
+	if aCount > 0 {
+		aStart++
+	}
+	if bCount > 0 {
+		bStart++
+	}
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+	for _, op := range hunk {
+		out.WriteByte(op.kind)
+		out.WriteString(op.text)
+		out.WriteByte('\n')
+	}
+}
+
+// maxDiffCells bounds the LCS table. Past it, the lines between the common
+// prefix and suffix are diffed as a whole replacement.
+const maxDiffCells = 1 << 20
+
+// diffLines computes edit operations from the longest common subsequence of both lines lists.
+// Each op keeps how many lines of a and b were consumed before it.
+func diffLines(a, b []string) (ops []diffOp) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		ops = append(ops, diffOp{' ', a[prefix], prefix, prefix})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(ma)*len(mb) > maxDiffCells {
+		for i, line := range ma {
+			ops = append(ops, diffOp{'-', line, prefix + i, prefix})
+		}
+		for j, line := range mb {
+			ops = append(ops, diffOp{'+', line, prefix + len(ma), prefix + j})
+		}
+	} else {
+		ops = append(ops, lcsLines(ma, mb, prefix)...)
+	}
+	for k := suffix; k > 0; k-- {
+		i, j := len(a)-k, len(b)-k
+		ops = append(ops, diffOp{' ', a[i], i, j})
+	}
+	return
+}
+
+// lcsLines diffs lines found past offset lines common to both texts.
+func lcsLines(a, b []string, offset int) (ops []diffOp) {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i], offset + i, offset + j})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, diffOp{'+', b[j], offset + i, offset + j})
+			j++
+		default:
+			ops = append(ops, diffOp{'-', a[i], offset + i, offset + j})
+			i++
+		}
+	}
+	return
+}
+
+func splitLines(s string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
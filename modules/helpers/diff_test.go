@@ -0,0 +1,48 @@
+package helpers
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	Convey("Unified diffs between texts", t, func() {
+		Convey("Same texts have no diff", func() {
+			So(UnifiedDiff("a\nb", "a\nb", "1", "2"), ShouldEqual, "")
+		})
+
+		Convey("Changed lines are shown with context", func() {
+			diff := UnifiedDiff("a\nb\nc", "a\nx\nc\nd", "1", "2")
+			So(diff, ShouldEqual, "--- 1\n+++ 2\n@@ -1,3 +1,4 @@\n a\n-b\n+x\n c\n+d\n")
+		})
+
+		Convey("Distant changes are split into hunks", func() {
+			from := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12"
+			to := "0\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n13"
+			diff := UnifiedDiff(from, to, "1", "2")
+			So(diff, ShouldEqual, "--- 1\n+++ 2\n@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+13\n")
+		})
+
+		Convey("Large rewrites are diffed as a replacement", func() {
+			var from, to []string
+			for i := 0; i < 1100; i++ {
+				from = append(from, "a"+strconv.Itoa(i))
+				to = append(to, "b"+strconv.Itoa(i))
+			}
+			text := func(lines []string) string {
+				return "head\n" + strings.Join(lines, "\n") + "\ntail"
+			}
+			diff := UnifiedDiff(text(from), text(to), "1", "2")
+			So(diff, ShouldStartWith, "--- 1\n+++ 2\n@@ -1,1102 +1,1102 @@\n head\n-a0\n")
+			So(diff, ShouldContainSubstring, "-a1099\n+b0\n")
+			So(diff, ShouldEndWith, "+b1099\n tail\n")
+		})
+
+		Convey("Empty texts count from line zero", func() {
+			So(UnifiedDiff("", "a", "1", "2"), ShouldEqual, "--- 1\n+++ 2\n@@ -0,0 +1,1 @@\n+a\n")
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+const (
+	hllPrecision = 10
+	hllRegisters = 1 << hllPrecision
+)
+
+// HyperLogLog sketch estimating the count of distinct items it has seen,
+// using 1KB of registers with a standard error around 3%.
+type HyperLogLog []byte
+
+// NewHyperLogLog sketch with no items.
+func NewHyperLogLog() HyperLogLog {
+	return make(HyperLogLog, hllRegisters)
+}
+
+// Valid sketch registers, as stored ones could be corrupted or missing.
+func (h HyperLogLog) Valid() bool {
+	return len(h) == hllRegisters
+}
+
+// Add item to the sketch, tells whether registers changed.
+func (h HyperLogLog) Add(item []byte) bool {
+	hash := fnv.New64a()
+	hash.Write(item)
+	x := mix64(hash.Sum64())
+	index := x >> (64 - hllPrecision)
+	rank := byte(bits.LeadingZeros64(x<<hllPrecision|1<<(hllPrecision-1)) + 1)
+	if rank > h[index] {
+		h[index] = rank
+		return true
+	}
+	return false
+}
+
+// Merge other sketch registers into this one.
+func (h HyperLogLog) Merge(other HyperLogLog) {
+	for i, r := range other {
+		if r > h[i] {
+			h[i] = r
+		}
+	}
+}
+
+// Count estimate of distinct items.
+func (h HyperLogLog) Count() uint64 {
+	m := float64(hllRegisters)
+	sum, zeros := 0.0, 0
+	for _, r := range h {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+
+	// Small cardinalities are better estimated by linear counting.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// mix64 spreads hash bits evenly (splitmix64 finalizer).
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
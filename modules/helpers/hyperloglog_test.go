@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	Convey("HyperLogLog estimates distinct items", t, func() {
+		Convey("Repeated items count once", func() {
+			h := NewHyperLogLog()
+			So(h.Add([]byte("a")), ShouldBeTrue)
+			So(h.Add([]byte("a")), ShouldBeFalse)
+			h.Add([]byte("b"))
+			So(h.Count(), ShouldEqual, 2)
+		})
+
+		Convey("Large counts stay within a few percent", func() {
+			h := NewHyperLogLog()
+			for n := 0; n < 100000; n++ {
+				h.Add([]byte(strconv.Itoa(n)))
+				h.Add([]byte(strconv.Itoa(n)))
+			}
+			So(float64(h.Count()), ShouldAlmostEqual, 100000, 10000)
+		})
+
+		Convey("Merged sketches count their union", func() {
+			a, b := NewHyperLogLog(), NewHyperLogLog()
+			for n := 0; n < 500; n++ {
+				a.Add([]byte(strconv.Itoa(n)))
+				b.Add([]byte(strconv.Itoa(n + 250)))
+			}
+			a.Merge(b)
+			So(float64(a.Count()), ShouldAlmostEqual, 750, 40)
+		})
+
+		Convey("Stored registers are checked", func() {
+			So(NewHyperLogLog().Valid(), ShouldBeTrue)
+			So(HyperLogLog([]byte("junk")).Valid(), ShouldBeFalse)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"github.com/markbates/goth"
 	logging "github.com/op/go-logging"
+	"github.com/tryanzu/core/core/content"
 	"github.com/tryanzu/core/deps"
 	"github.com/tryanzu/core/modules/exceptions"
 	"github.com/tryanzu/core/modules/helpers"
@@ -83,6 +84,12 @@ func (module *Module) SignUp(email, username, password, referral string) (*One,
 		}
 	}
 
+	if module.AllowedUsername(id, username) == false {
+		return nil, exceptions.OutOfBounds{
+			Msg: "Username not allowed by site rules.",
+		}
+	}
+
 	// Check if user already exists using that email
 	unique, err := deps.Container.Mgo().C("users").Find(bson.M{
 		"$or": []bson.M{
@@ -145,6 +152,21 @@ func (module *Module) SignUp(email, username, password, referral string) (*One,
 	return user, nil
 }
 
+// AllowedUsername by site content filters. Usernames cannot be masked nor held, so any match rejects them.
+func (module *Module) AllowedUsername(id bson.ObjectId, username string) bool {
+	filtered, err := content.Filter(deps.Container, content.Text{
+		ID:      id,
+		UserID:  id,
+		Related: "user",
+		Content: username,
+	})
+	if err != nil {
+		return false
+	}
+	text := filtered.(content.Text)
+	return text.Held == false && text.Content == username
+}
+
 func (m *Module) computeNickname(nicknames ...string) (string, error) {
 	var nickname string
 	for _, name := range nicknames {